@@ -0,0 +1,103 @@
+// Package bullwler — библиотечный API аудитора: анализ страницы и сканирование сайта
+// с теми же проверками, что и в командной строке.
+//
+//	rep, err := bullwler.Analyze(ctx, "https://example.com/post", bullwler.WithHTTPClient(client))
+//	site, err := bullwler.Crawl(ctx, "https://example.com", bullwler.WithMaxPages(100))
+package bullwler
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"bullwler/internal/analyzer"
+	"bullwler/internal/crawler"
+	"bullwler/internal/i18n"
+	"bullwler/internal/report"
+)
+
+// Report — отчёт по одной странице
+type Report = report.SEOReport
+
+// SiteReport — сводный отчёт по сайту
+type SiteReport = report.SiteReport
+
+// CrawlResult — результат анализа одной страницы в рамках сканирования
+type CrawlResult = report.CrawlResult
+
+// Finding — замечание аудита
+type Finding = report.Finding
+
+// Evidence — фрагмент страницы, подтверждающий замечание
+type Evidence = report.Evidence
+
+// Severity — серьёзность замечания
+type Severity = report.Severity
+
+// Category — раздел аудита, к которому относится замечание
+type Category = report.Category
+
+// Уровни серьёзности
+const (
+	SeverityError   = report.SeverityError
+	SeverityWarning = report.SeverityWarning
+	SeverityInfo    = report.SeverityInfo
+)
+
+// Разделы аудита
+const (
+	CategoryNetwork     = report.CategoryNetwork
+	CategorySEO         = report.CategorySEO
+	CategoryA11y        = report.CategoryA11y
+	CategorySecurity    = report.CategorySecurity
+	CategoryPerformance = report.CategoryPerformance
+	CategoryAI          = report.CategoryAI
+)
+
+// Analyze — анализирует одну страницу. Ошибки загрузки страницы (недоступность,
+// код ответа, некорректный HTML) не возвращаются, а попадают в отчёт замечаниями
+// категории network; ошибка возвращается для некорректного URL, неверных опций
+// и отменённого ctx.
+func Analyze(ctx context.Context, rawURL string, opts ...Option) (*Report, error) {
+	o, err := newOptions(rawURL, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateURL(rawURL); err != nil {
+		return nil, err
+	}
+	rep := analyzer.AnalyzeURL(ctx, rawURL, o.analyzerOptions()...)
+	if err := ctx.Err(); err != nil {
+		return rep, err
+	}
+	return rep, nil
+}
+
+// Crawl — сканирует сайт, начиная с rawURL, и возвращает сводный отчёт.
+// При отмене ctx или истечении общего лимита времени возвращается отчёт
+// по уже проанализированным страницам с причиной остановки в поле Stopped.
+func Crawl(ctx context.Context, rawURL string, opts ...Option) (*SiteReport, error) {
+	o, err := newOptions(rawURL, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateURL(rawURL); err != nil {
+		return nil, err
+	}
+	return crawler.NewCrawler(o.crawlerOptions()...).CrawlSite(ctx, rawURL)
+}
+
+// validateURL — проверяет, что URL абсолютный и использует http или https
+func validateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return errors.New(i18n.T("err.api.url", rawURL))
+	}
+	return nil
+}
+
+// SetLang — выбирает язык сообщений, названий правил и рекомендаций в отчётах: en или ru.
+// Настройка глобальная для процесса.
+func SetLang(lang string) error {
+	return i18n.SetLang(lang)
+}
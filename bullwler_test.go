@@ -0,0 +1,87 @@
+package bullwler_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bullwler"
+)
+
+func alwaysFails(id string) bullwler.Rule {
+	return bullwler.NewRule(bullwler.RuleMeta{RuleInfo: bullwler.RuleInfo{
+		ID:       id,
+		Category: bullwler.CategorySEO,
+		Severity: bullwler.SeverityError,
+		Title:    id,
+	}}, func(c *bullwler.RuleContext) {
+		c.Add(id+" failed", nil)
+	})
+}
+
+func hasFinding(rep *bullwler.Report, id string) bool {
+	for _, f := range rep.Findings {
+		if f.RuleID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCustomRulesAreScopedToCall(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html><head><title>t</title></head><body><h1>t</h1></body></html>"))
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	repA, err := bullwler.Analyze(ctx, srv.URL, bullwler.WithRule(alwaysFails("test.rule-a")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	repB, err := bullwler.Analyze(ctx, srv.URL, bullwler.WithRule(alwaysFails("test.rule-b")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !hasFinding(repA, "test.rule-a") || hasFinding(repA, "test.rule-b") {
+		t.Errorf("first call: want only test.rule-a findings, got %+v", repA.Findings)
+	}
+	if !hasFinding(repB, "test.rule-b") || hasFinding(repB, "test.rule-a") {
+		t.Errorf("second call: want only test.rule-b findings, got %+v", repB.Findings)
+	}
+
+	for _, info := range bullwler.Rules() {
+		if strings.HasPrefix(info.ID, "test.") {
+			t.Errorf("global catalog contains custom rule %s", info.ID)
+		}
+	}
+
+	var sarif, junit bytes.Buffer
+	if err := repB.WriteSARIF(&sarif); err != nil {
+		t.Fatal(err)
+	}
+	if err := repB.WriteJUnit(&junit); err != nil {
+		t.Fatal(err)
+	}
+	for name, out := range map[string]string{"SARIF": sarif.String(), "JUnit": junit.String()} {
+		if strings.Contains(out, "test.rule-a") {
+			t.Errorf("%s of the second call mentions the first call's rule", name)
+		}
+		if !strings.Contains(out, "test.rule-b") {
+			t.Errorf("%s of the second call misses its own rule", name)
+		}
+	}
+}
+
+func TestCustomRuleCannotReplaceBuiltin(t *testing.T) {
+	_, err := bullwler.Analyze(context.Background(), "https://example.com",
+		bullwler.WithRule(alwaysFails("seo.title.missing")))
+	if err == nil {
+		t.Fatal("custom rule with a built-in ID: want error, got nil")
+	}
+}
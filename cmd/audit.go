@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"bullwler/internal/analyzer"
+	"bullwler/internal/baseline"
+	"bullwler/internal/crawler"
+	"bullwler/internal/events"
+	"bullwler/internal/gate"
+	"bullwler/internal/history"
+	"bullwler/internal/i18n"
+	"bullwler/internal/report"
+
+	"github.com/fatih/color"
+)
+
+// auditMode — режим аудита
+type auditMode int
+
+const (
+	// modeAuto — краулинг для корня сайта, иначе аудит одной страницы
+	modeAuto auditMode = iota
+	// modePage — аудит одной страницы
+	modePage
+	// modeSite — аудит сайта с краулером
+	modeSite
+)
+
+func (m auditMode) command() string {
+	switch m {
+	case modePage:
+		return "audit"
+	case modeSite:
+		return "crawl"
+	default:
+		return "bullwler"
+	}
+}
+
+func runAudit(args []string, mode auditMode) int {
+	fs := flag.NewFlagSet(mode.command(), flag.ContinueOnError)
+	var cf commonFlags
+	cf.register(fs)
+	var crf *crawlFlags
+	if mode != modePage {
+		crf = &crawlFlags{}
+		crf.register(fs)
+	}
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.usage.audit", mode.command()))
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseArgs(fs, args)
+	if err == flag.ErrHelp {
+		return exitOK
+	}
+	if err != nil {
+		return exitError
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		return exitError
+	}
+
+	cfg, err := resolveConfig(fs, &cf, crf)
+	if err != nil {
+		return fail("err.cli.config", err)
+	}
+	if err := setupColor(cf.color, cf.output != ""); err != nil {
+		return fail("%v", err)
+	}
+
+	qualityGate, err := gate.Parse(cfg.FailOn...)
+	if err != nil {
+		return fail("err.cli.fail-on", err)
+	}
+
+	targetURL := normalizeTarget(positional[0])
+	fetcher, err := cfg.Fetcher(targetURL)
+	if err != nil {
+		return fail("err.cli.http", err)
+	}
+	analyzeOpts, reg, err := analyzerOptions(cfg, fetcher)
+	if err != nil {
+		return fail("err.cli.rules", err)
+	}
+
+	// Ctrl-C отменяет сканирование: выводится отчёт по уже проанализированным страницам
+	ctx, stop := interruptContext()
+	defer stop()
+
+	if mode == modeAuto {
+		mode = modePage
+		if isSiteRoot(targetURL) {
+			mode = modeSite
+		}
+	}
+
+	// В формате ndjson события пишутся по ходу сканирования, итоговый отчёт не выводится
+	var stream *events.Writer
+	if cfg.Output.Format == "ndjson" {
+		out, err := openOutput(cf.output)
+		if err != nil {
+			return fail("err.cli.write-report", err)
+		}
+		defer out.Close()
+		stream = events.NewWriter(out)
+	}
+
+	var rep outputWriter
+	var pages []*report.SEOReport
+	var siteRep *report.SiteReport
+	var pageRep *report.SEOReport
+	if mode == modeSite {
+		crawlOpts, err := crawlerOptions(cfg, fetcher, reg, analyzeOpts)
+		if err != nil {
+			return fail("err.cli.config", err)
+		}
+		if stream != nil {
+			crawlOpts = append(crawlOpts, crawler.WithEvents(stream.Emit))
+		}
+		siteRep, err = crawler.NewCrawler(crawlOpts...).CrawlSite(ctx, targetURL)
+		if err != nil {
+			return fail("err.cli.crawl", err)
+		}
+		rep = siteRep
+		pages = siteRep.Reports()
+
+		// Снимок сохраняется до применения базовой линии, чтобы история отражала все замечания.
+		// Прерванное сканирование в историю не попадает, чтобы не искажать динамику.
+		if cfg.History.Enabled && siteRep.Stopped != report.StopCanceled {
+			snap, err := history.Open(cfg.History.Dir).Save(siteRep, time.Now())
+			if err != nil {
+				return fail("%v", err)
+			}
+			fmt.Fprintf(os.Stderr, "📈 %s\n", i18n.T("log.history.saved", snap.Path))
+		}
+	} else {
+		started := time.Now()
+		if stream != nil {
+			stream.Emit(events.PageStarted(targetURL, 0))
+		}
+		pageRep = analyzer.AnalyzeURL(ctx, targetURL, analyzeOpts...)
+		if stream != nil {
+			stream.Emit(events.PageFinished(targetURL, 0, pageRep))
+			stream.Emit(events.CrawlFinished([]report.CrawlResult{{URL: targetURL, Report: pageRep}}, started, ""))
+		}
+		rep = pageRep
+		pages = []*report.SEOReport{pageRep}
+	}
+
+	interrupted := ctx.Err() != nil
+	switch cf.baseline {
+	case "write":
+		if interrupted {
+			fmt.Fprintf(os.Stderr, "⚠️  %s\n", i18n.T("log.baseline.skipped"))
+			break
+		}
+		b := baseline.New(pages)
+		if err := b.Save(cfg.BaselineFile); err != nil {
+			return fail("err.cli.baseline-save", err)
+		}
+		fmt.Fprintf(os.Stderr, "📌 %s\n", i18n.T("log.baseline.written", cfg.BaselineFile, len(b.Entries)))
+	case "check":
+		b, err := baseline.Load(cfg.BaselineFile)
+		if err != nil {
+			return fail("%v", err)
+		}
+		delta := b.Apply(pages)
+		delta.File = cfg.BaselineFile
+		if siteRep != nil {
+			siteRep.Baseline = delta
+		} else {
+			pageRep.Baseline = delta
+		}
+	}
+
+	var breaches []gate.Breach
+	if siteRep != nil {
+		breaches = qualityGate.CheckSite(siteRep)
+	} else {
+		breaches = qualityGate.CheckPage(pageRep)
+	}
+
+	if stream != nil {
+		if err := stream.Err(); err != nil {
+			return fail("err.cli.write-events", err)
+		}
+	} else if err := writeOutput(cfg.Output.Format, cf.output, rep); err != nil {
+		return fail("err.cli.write-report", err)
+	}
+
+	if interrupted {
+		return fail("err.cli.interrupted")
+	}
+	if len(breaches) > 0 {
+		printBreaches(breaches)
+		return exitGateFailed
+	}
+	return exitOK
+}
+
+// interruptContext — контекст, отменяемый по Ctrl-C или SIGTERM. После отмены обработчик
+// сигналов снимается, и повторное нажатие завершает процесс сразу.
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx, stop
+}
+
+// printBreaches — выводит в stderr краткую сводку нарушенных условий
+func printBreaches(breaches []gate.Breach) {
+	red := color.New(color.FgRed).SprintFunc()
+	fmt.Fprintln(os.Stderr, red("❌ "+i18n.T("print.gate.failed")))
+	for _, b := range breaches {
+		fmt.Fprintf(os.Stderr, "  • [%s] %s\n", b.Condition, b.Message)
+	}
+}
+
+func isSiteRoot(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	path := strings.TrimRight(u.Path, "/")
+	return path == "" || path == "/index.html" || path == "/index.htm"
+}
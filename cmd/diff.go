@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"bullwler/internal/diff"
+	"bullwler/internal/i18n"
+	"bullwler/internal/report"
+)
+
+func runDiff(args []string) int {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	format := fs.String("format", "text", i18n.T("flag.format.text-json"))
+	output := fs.String("o", "", i18n.T("flag.output.result"))
+	colorMode := fs.String("color", "auto", i18n.T("flag.color"))
+	registerLang(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.usage.diff"))
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseArgs(fs, args)
+	if err == flag.ErrHelp {
+		return exitOK
+	}
+	if err != nil {
+		return exitError
+	}
+	if len(positional) != 2 {
+		fs.Usage()
+		return exitError
+	}
+	if *format != "text" && *format != "json" {
+		return fail("err.cli.format", *format)
+	}
+	if err := setupColor(*colorMode, *output != ""); err != nil {
+		return fail("%v", err)
+	}
+
+	oldRep, err := report.LoadJSON(positional[0])
+	if err != nil {
+		return fail("err.cli.read-report", err)
+	}
+	newRep, err := report.LoadJSON(positional[1])
+	if err != nil {
+		return fail("err.cli.read-report", err)
+	}
+
+	result := diff.Compare(oldRep, newRep)
+
+	err = withOutput(*output, func(w io.Writer) error {
+		if *format == "json" {
+			return result.WriteJSON(w)
+		}
+		result.Fprint(w)
+		return nil
+	})
+	if err != nil {
+		return fail("err.cli.write", err)
+	}
+	return exitOK
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"strings"
+	"time"
+
+	"bullwler/internal/analyzer"
+	"bullwler/internal/baseline"
+	"bullwler/internal/config"
+	"bullwler/internal/crawler"
+	"bullwler/internal/fetch"
+	"bullwler/internal/i18n"
+	"bullwler/internal/rules"
+)
+
+// commonFlags — флаги, общие для команд audit, crawl и sitemap
+type commonFlags struct {
+	configPath   string
+	format       string
+	output       string
+	color        string
+	userAgent    string
+	timeout      time.Duration
+	failOn       string
+	baseline     string
+	baselineFile string
+	headers      stringList
+	cookies      stringList
+	proxy        string
+	caFiles      stringList
+	maxBodySize  int64
+}
+
+func (f *commonFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.format, "format", "", i18n.T("flag.format"))
+	f.registerOutput(fs, i18n.T("flag.output"))
+	f.registerHTTP(fs)
+	fs.StringVar(&f.baseline, "baseline", "", i18n.T("flag.baseline"))
+	fs.StringVar(&f.baselineFile, "baseline-file", "", i18n.T("flag.baseline-file", baseline.DefaultFile))
+	fs.StringVar(&f.failOn, "fail-on", "", i18n.T("flag.fail-on"))
+	registerLang(fs)
+}
+
+// registerOutput — флаги конфигурации, файла вывода и цвета
+func (f *commonFlags) registerOutput(fs *flag.FlagSet, outputUsage string) {
+	fs.StringVar(&f.configPath, "config", "", i18n.T("flag.config"))
+	fs.StringVar(&f.output, "o", "", outputUsage)
+	fs.StringVar(&f.output, "output", "", i18n.T("flag.output.alias"))
+	fs.StringVar(&f.color, "color", "auto", i18n.T("flag.color"))
+}
+
+// registerHTTP — флаги загрузки: идентичность краулера, таймаут страницы и настройки HTTP-клиента
+func (f *commonFlags) registerHTTP(fs *flag.FlagSet) {
+	fs.StringVar(&f.userAgent, "user-agent", "", i18n.T("flag.user-agent", strings.Join(fetch.PresetNames(), ", ")))
+	fs.DurationVar(&f.timeout, "timeout", 0, i18n.T("flag.timeout"))
+	fs.Var(&f.headers, "header", i18n.T("flag.header"))
+	fs.Var(&f.cookies, "cookie", i18n.T("flag.cookie"))
+	fs.StringVar(&f.proxy, "proxy", "", i18n.T("flag.proxy"))
+	fs.Var(&f.caFiles, "ca-file", i18n.T("flag.ca-file"))
+	fs.Int64Var(&f.maxBodySize, "max-body-size", 0, i18n.T("flag.max-body-size"))
+}
+
+// stringList — повторяемый строковый флаг
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ", ") }
+
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+// crawlFlags — ограничения краулера
+type crawlFlags struct {
+	depth        int
+	pages        int
+	concurrency  int
+	crawlTimeout time.Duration
+	history      bool
+	historyDir   string
+	sitemaps     bool
+	stateDir     string
+	resume       bool
+	include      stringList
+	exclude      stringList
+	includeGlob  stringList
+	excludeGlob  stringList
+	pathPrefixes stringList
+	subdomains   bool
+	allowHosts   stringList
+	sectionPages int
+}
+
+func (f *crawlFlags) register(fs *flag.FlagSet) {
+	f.registerLimits(fs)
+	fs.BoolVar(&f.history, "history", false, i18n.T("flag.history"))
+	fs.StringVar(&f.historyDir, "history-dir", "", i18n.T("flag.history-dir"))
+}
+
+// registerLimits — флаги обхода сайта без сохранения в историю
+func (f *crawlFlags) registerLimits(fs *flag.FlagSet) {
+	fs.IntVar(&f.depth, "depth", 0, i18n.T("flag.depth"))
+	fs.IntVar(&f.pages, "pages", 0, i18n.T("flag.pages"))
+	fs.IntVar(&f.concurrency, "concurrency", 0, i18n.T("flag.concurrency"))
+	fs.DurationVar(&f.crawlTimeout, "crawl-timeout", 0, i18n.T("flag.crawl-timeout"))
+	fs.BoolVar(&f.sitemaps, "sitemaps", true, i18n.T("flag.sitemaps"))
+	fs.StringVar(&f.stateDir, "state-dir", "", i18n.T("flag.state-dir"))
+	fs.BoolVar(&f.resume, "resume", false, i18n.T("flag.resume"))
+	fs.Var(&f.include, "include", i18n.T("flag.include"))
+	fs.Var(&f.exclude, "exclude", i18n.T("flag.exclude"))
+	fs.Var(&f.includeGlob, "include-glob", i18n.T("flag.include-glob"))
+	fs.Var(&f.excludeGlob, "exclude-glob", i18n.T("flag.exclude-glob"))
+	fs.Var(&f.pathPrefixes, "path-prefix", i18n.T("flag.path-prefix"))
+	fs.BoolVar(&f.subdomains, "subdomains", false, i18n.T("flag.subdomains"))
+	fs.Var(&f.allowHosts, "allow-host", i18n.T("flag.allow-host"))
+	fs.IntVar(&f.sectionPages, "section-pages", 0, i18n.T("flag.section-pages"))
+}
+
+// parseArgs — разбирает флаги, допуская их после позиционных аргументов
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// visited — возвращает имена флагов, явно заданных в командной строке
+func visited(fs *flag.FlagSet) map[string]bool {
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	return set
+}
+
+// resolveConfig — загружает конфигурацию и применяет поверх неё флаги командной строки
+func resolveConfig(fs *flag.FlagSet, cf *commonFlags, crf *crawlFlags) (*config.Config, error) {
+	cfg, err := loadConfig(cf.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	set := visited(fs)
+	if set["format"] {
+		cfg.Output.Format = cf.format
+	}
+	if set["user-agent"] {
+		cfg.UserAgent = cf.userAgent
+	}
+	if set["baseline-file"] {
+		cfg.BaselineFile = cf.baselineFile
+	}
+	if cf.baseline != "" && cf.baseline != "write" && cf.baseline != "check" {
+		return nil, errors.New(i18n.T("err.cli.baseline-mode"))
+	}
+	if set["fail-on"] {
+		cfg.FailOn = []string{cf.failOn}
+	}
+	if set["timeout"] {
+		cfg.Timeouts.Page = config.Duration(cf.timeout)
+	}
+	for _, h := range cf.headers {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, errors.New(i18n.T("err.cli.header", h))
+		}
+		if cfg.HTTP.Headers == nil {
+			cfg.HTTP.Headers = make(map[string]string)
+		}
+		cfg.HTTP.Headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	cfg.HTTP.Cookies = append(cfg.HTTP.Cookies, cf.cookies...)
+	cfg.HTTP.CAFiles = append(cfg.HTTP.CAFiles, cf.caFiles...)
+	if set["proxy"] {
+		cfg.HTTP.Proxy = cf.proxy
+	}
+	if set["max-body-size"] {
+		cfg.HTTP.MaxBodySize = cf.maxBodySize
+	}
+	if crf != nil {
+		if set["depth"] {
+			cfg.Crawler.MaxDepth = crf.depth
+		}
+		if set["pages"] {
+			cfg.Crawler.MaxPages = crf.pages
+		}
+		if set["concurrency"] {
+			cfg.Crawler.Concurrency = crf.concurrency
+		}
+		if set["crawl-timeout"] {
+			cfg.Timeouts.Crawl = config.Duration(crf.crawlTimeout)
+		}
+		if set["history"] {
+			cfg.History.Enabled = crf.history
+		}
+		if set["history-dir"] {
+			cfg.History.Dir = crf.historyDir
+		}
+		if set["sitemaps"] {
+			cfg.Crawler.Sitemaps = crf.sitemaps
+		}
+		if set["state-dir"] {
+			cfg.Crawler.StateDir = crf.stateDir
+		}
+		cfg.Crawler.Resume = crf.resume
+		cfg.Include = append(cfg.Include, crf.include...)
+		cfg.Exclude = append(cfg.Exclude, crf.exclude...)
+		cfg.IncludeGlob = append(cfg.IncludeGlob, crf.includeGlob...)
+		cfg.ExcludeGlob = append(cfg.ExcludeGlob, crf.excludeGlob...)
+		cfg.Crawler.PathPrefixes = append(cfg.Crawler.PathPrefixes, crf.pathPrefixes...)
+		cfg.Crawler.AllowedHosts = append(cfg.Crawler.AllowedHosts, crf.allowHosts...)
+		if set["subdomains"] {
+			cfg.Crawler.Subdomains = crf.subdomains
+		}
+		if set["section-pages"] {
+			cfg.Crawler.SectionPages = crf.sectionPages
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if !validFormat(cfg.Output.Format) {
+		return nil, errors.New(i18n.T("err.cli.format", cfg.Output.Format))
+	}
+	return cfg, nil
+}
+
+// loadConfig — загружает конфигурацию и применяет заданный в ней язык, если он не указан флагом
+func loadConfig(path string) (*config.Config, error) {
+	var cfg *config.Config
+	var err error
+	if path != "" {
+		cfg, err = config.Load(path)
+	} else {
+		var wd string
+		if wd, err = os.Getwd(); err != nil {
+			return nil, err
+		}
+		cfg, err = config.Discover(wd)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Lang != "" && !langFromFlag {
+		if err := applyLang(cfg.Lang); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// analyzerOptions — собирает опции анализа страницы из конфигурации
+// и возвращает реестр правил, который в них передан
+func analyzerOptions(cfg *config.Config, f *fetch.Fetcher) ([]analyzer.Option, *rules.Registry, error) {
+	reg, err := cfg.ApplyRules(rules.Default())
+	if err != nil {
+		return nil, nil, err
+	}
+	opts := []analyzer.Option{
+		analyzer.WithRules(reg),
+		analyzer.WithTimeout(time.Duration(cfg.Timeouts.Page)),
+		analyzer.WithFetcher(f),
+	}
+	return opts, reg, nil
+}
+
+// crawlerOptions — собирает опции краулера из конфигурации
+func crawlerOptions(cfg *config.Config, f *fetch.Fetcher, reg *rules.Registry, analyzeOpts []analyzer.Option) ([]crawler.Option, error) {
+	include, exclude, err := cfg.Patterns()
+	if err != nil {
+		return nil, err
+	}
+	opts := []crawler.Option{
+		crawler.WithMaxDepth(cfg.Crawler.MaxDepth),
+		crawler.WithMaxPages(cfg.Crawler.MaxPages),
+		crawler.WithConcurrency(cfg.Crawler.Concurrency),
+		crawler.WithTimeout(time.Duration(cfg.Timeouts.Crawl)),
+		crawler.WithURLFilter(include, exclude),
+		crawler.WithAnalyzerOptions(analyzeOpts...),
+		crawler.WithRules(reg),
+		crawler.WithFetcher(f),
+		crawler.WithSitemaps(cfg.Crawler.Sitemaps),
+		crawler.WithPathPrefixes(cfg.Crawler.PathPrefixes...),
+		crawler.WithSubdomains(cfg.Crawler.Subdomains),
+		crawler.WithAllowedHosts(cfg.Crawler.AllowedHosts...),
+		crawler.WithSectionBudget(cfg.Crawler.SectionPages),
+	}
+	if cfg.Crawler.StateDir != "" {
+		opts = append(opts, crawler.WithState(cfg.Crawler.StateDir, cfg.Crawler.Resume))
+	}
+	return opts, nil
+}
+
+func normalizeTarget(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if !analyzer.HasScheme(raw) {
+		raw = "https://" + raw
+	}
+	return raw
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"bullwler/internal/history"
+	"bullwler/internal/i18n"
+)
+
+func runHistory(args []string) int {
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	configPath := fs.String("config", "", i18n.T("flag.config"))
+	dir := fs.String("dir", "", i18n.T("flag.history.dir"))
+	limit := fs.Int("limit", 10, i18n.T("flag.history.limit"))
+	format := fs.String("format", "text", i18n.T("flag.format.text-json"))
+	output := fs.String("o", "", i18n.T("flag.output.result"))
+	colorMode := fs.String("color", "auto", i18n.T("flag.color"))
+	registerLang(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.usage.history"))
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseArgs(fs, args)
+	if err == flag.ErrHelp {
+		return exitOK
+	}
+	if err != nil {
+		return exitError
+	}
+	if len(positional) > 1 {
+		fs.Usage()
+		return exitError
+	}
+	if *format != "text" && *format != "json" {
+		return fail("err.cli.format", *format)
+	}
+	if err := setupColor(*colorMode, *output != ""); err != nil {
+		return fail("%v", err)
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		return fail("err.cli.config", err)
+	}
+	if *dir != "" {
+		cfg.History.Dir = *dir
+	}
+	store := history.Open(cfg.History.Dir)
+
+	var result interface {
+		Fprint(w io.Writer)
+		WriteJSON(w io.Writer) error
+	}
+	if len(positional) == 0 {
+		sites, err := store.Sites()
+		if err != nil {
+			return fail("%v", err)
+		}
+		result = sites
+	} else {
+		trend, err := store.Trend(positional[0], *limit)
+		if err != nil {
+			return fail("%v", err)
+		}
+		result = trend
+	}
+
+	err = withOutput(*output, func(w io.Writer) error {
+		if *format == "json" {
+			return result.WriteJSON(w)
+		}
+		result.Fprint(w)
+		return nil
+	})
+	if err != nil {
+		return fail("err.cli.write", err)
+	}
+	return exitOK
+}
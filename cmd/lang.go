@@ -0,0 +1,78 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"strings"
+
+	"bullwler/internal/i18n"
+)
+
+// langFromFlag — язык задан флагом --lang и не переопределяется конфигурацией
+var langFromFlag bool
+
+// setupLang — выбирает язык сообщений до разбора флагов, чтобы справка тоже была переведена.
+// Приоритет: --lang, затем поле lang конфигурации (см. loadConfig), затем LC_ALL/LC_MESSAGES/LANG.
+func setupLang(args []string) error {
+	if lang := scanLang(args); lang != "" {
+		langFromFlag = true
+		return applyLang(lang)
+	}
+	if lang := i18n.Detect(os.Getenv); lang != "" {
+		return i18n.SetLang(lang)
+	}
+	return nil
+}
+
+// scanLang — ищет значение --lang среди аргументов командной строки
+func scanLang(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "lang" {
+			continue
+		}
+		if hasValue {
+			return value
+		}
+		if i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// trimGlobalLang — убирает --lang, указанный перед командой: bullwler --lang en audit <URL>
+func trimGlobalLang(args []string) []string {
+	for len(args) > 0 {
+		name, _, hasValue := strings.Cut(strings.TrimLeft(args[0], "-"), "=")
+		if !strings.HasPrefix(args[0], "-") || name != "lang" {
+			break
+		}
+		if hasValue || len(args) == 1 {
+			args = args[1:]
+		} else {
+			args = args[2:]
+		}
+	}
+	return args
+}
+
+// applyLang — включает встроенный язык (en, ru) или загружает каталог из JSON-файла
+func applyLang(lang string) error {
+	if strings.HasSuffix(lang, ".json") {
+		code, err := i18n.LoadFile(lang)
+		if err != nil {
+			return err
+		}
+		lang = code
+	}
+	return i18n.SetLang(lang)
+}
+
+// registerLang — регистрирует флаг --lang; значение уже применено в setupLang
+func registerLang(fs *flag.FlagSet) {
+	fs.String("lang", "", i18n.T("flag.lang"))
+}
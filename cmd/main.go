@@ -1,52 +1,68 @@
 package main
 
 import (
-	"net/url"
+	"fmt"
 	"os"
-	"strings"
 
-	"bullwler/internal/analyzer"
-	"bullwler/internal/crawler"
+	"bullwler/internal/i18n"
 
 	"github.com/fatih/color"
 )
 
+// version — версия сборки, задаётся через -ldflags "-X main.version=..."
+var version = "dev"
+
+// Коды завершения
+const (
+	// exitOK — аудит выполнен, условия проверки качества соблюдены
+	exitOK = 0
+	// exitGateFailed — аудит выполнен, но нарушены условия --fail-on
+	exitGateFailed = 1
+	// exitError — ошибка запуска или работы инструмента
+	exitError = 2
+)
+
 func main() {
-	if len(os.Args) < 2 {
-		color.Red("Использование: bullwler <URL>")
-		os.Exit(1)
-	}
+	os.Exit(run(os.Args[1:]))
+}
 
-	targetURL := os.Args[1]
-	if !analyzer.HasScheme(targetURL) {
-		targetURL = "https://" + targetURL
+func run(args []string) int {
+	if err := setupLang(args); err != nil {
+		return fail("%v", err)
+	}
+	args = trimGlobalLang(args)
+	usage := i18n.T("cli.usage")
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return exitError
 	}
 
-	isRoot := isSiteRoot(targetURL)
-
-	if isRoot {
-		c := crawler.NewCrawler(
-			crawler.WithMaxDepth(3),
-			crawler.WithMaxPages(30),
-			crawler.WithConcurrency(5),
-		)
-		siteRep, err := c.CrawlSite(targetURL)
-		if err != nil {
-			color.Red("Ошибка сканирования сайта: %v", err)
-			os.Exit(1)
-		}
-		siteRep.Print()
-	} else {
-		rep := analyzer.AnalyzeURL(targetURL)
-		rep.Print()
+	switch args[0] {
+	case "audit":
+		return runAudit(args[1:], modePage)
+	case "crawl":
+		return runAudit(args[1:], modeSite)
+	case "rules":
+		return runRules(args[1:])
+	case "diff":
+		return runDiff(args[1:])
+	case "history":
+		return runHistory(args[1:])
+	case "sitemap":
+		return runSitemap(args[1:])
+	case "version", "--version":
+		fmt.Printf("bullwler %s\n", version)
+		return exitOK
+	case "help", "-h", "-help", "--help":
+		fmt.Println(usage)
+		return exitOK
+	default:
+		return runAudit(args, modeAuto)
 	}
 }
 
-func isSiteRoot(rawURL string) bool {
-	u, err := url.Parse(rawURL)
-	if err != nil {
-		return false
-	}
-	path := strings.TrimRight(u.Path, "/")
-	return path == "" || path == "/index.html" || path == "/index.htm"
+// fail — выводит ошибку в stderr; format — ключ каталога сообщений или строка формата
+func fail(format string, args ...any) int {
+	color.New(color.FgRed).Fprintln(os.Stderr, i18n.T(format, args...))
+	return exitError
 }
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"bullwler/internal/i18n"
+
+	"github.com/fatih/color"
+)
+
+var formats = []string{"text", "json", "sarif", "html", "markdown", "junit", "csv", "tsv", "ndjson"}
+
+func validFormat(f string) bool {
+	for _, known := range formats {
+		if f == known {
+			return true
+		}
+	}
+	return false
+}
+
+// outputWriter — отчёт, поддерживающий все форматы вывода
+type outputWriter interface {
+	Fprint(w io.Writer)
+	WriteJSON(w io.Writer) error
+	WriteSARIF(w io.Writer) error
+	WriteHTML(w io.Writer) error
+	WriteMarkdown(w io.Writer) error
+	WriteJUnit(w io.Writer) error
+	WriteCSV(w io.Writer, comma rune) error
+	WriteFindingsCSV(w io.Writer, comma rune) error
+}
+
+// setupColor — включает или выключает цветной вывод
+func setupColor(mode string, toFile bool) error {
+	switch mode {
+	case "auto":
+		if toFile {
+			color.NoColor = true
+		}
+	case "always":
+		color.NoColor = false
+	case "never":
+		color.NoColor = true
+	default:
+		return errors.New(i18n.T("err.cli.color", mode))
+	}
+	return nil
+}
+
+// writeOutput — записывает отчёт в выбранном формате в файл или stdout
+func writeOutput(format, path string, rep outputWriter) error {
+	if format == "csv" || format == "tsv" {
+		return writeTables(format, path, rep)
+	}
+	return withOutput(path, func(w io.Writer) error {
+		switch format {
+		case "json":
+			return rep.WriteJSON(w)
+		case "sarif":
+			return rep.WriteSARIF(w)
+		case "html":
+			return rep.WriteHTML(w)
+		case "markdown":
+			return rep.WriteMarkdown(w)
+		case "junit":
+			return rep.WriteJUnit(w)
+		default:
+			rep.Fprint(w)
+			return nil
+		}
+	})
+}
+
+// writeTables — плоская выгрузка: страницы в path, замечания в соседний файл *.findings.<format>.
+// При выводе в stdout выгружаются только страницы.
+func writeTables(format, path string, rep outputWriter) error {
+	comma := ','
+	if format == "tsv" {
+		comma = '\t'
+	}
+	err := withOutput(path, func(w io.Writer) error {
+		return rep.WriteCSV(w, comma)
+	})
+	if err != nil || path == "" {
+		return err
+	}
+
+	findingsPath := findingsFile(path, format)
+	if err := withOutput(findingsPath, func(w io.Writer) error {
+		return rep.WriteFindingsCSV(w, comma)
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "📄 %s\n", i18n.T("log.findings.written", findingsPath))
+	return nil
+}
+
+// findingsFile — имя файла замечаний рядом с файлом страниц: pages.csv → pages.findings.csv
+func findingsFile(path, format string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + ".findings." + format
+}
+
+// withOutput — вызывает write для файла path или для stdout, если путь пуст
+func withOutput(path string, write func(w io.Writer) error) (err error) {
+	w, err := openOutput(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := w.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	return write(w)
+}
+
+// openOutput — открывает файл path для записи или возвращает stdout, если путь пуст
+func openOutput(path string) (io.WriteCloser, error) {
+	if path == "" {
+		return nopCloser{os.Stdout}, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.cli.create-output"), err)
+	}
+	return f, nil
+}
+
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
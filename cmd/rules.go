@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"bullwler/internal/i18n"
+	"bullwler/internal/report"
+	"bullwler/internal/rules"
+
+	"github.com/fatih/color"
+)
+
+// ruleListing — строка вывода команды rules
+type ruleListing struct {
+	report.RuleInfo
+	Enabled bool               `json:"enabled"`
+	Params  map[string]float64 `json:"params,omitempty"`
+	Points  int                `json:"points,omitempty"`
+}
+
+func runRules(args []string) int {
+	fs := flag.NewFlagSet("rules", flag.ContinueOnError)
+	configPath := fs.String("config", "", i18n.T("flag.config"))
+	format := fs.String("format", "text", i18n.T("flag.format.text-json"))
+	category := fs.String("category", "", i18n.T("flag.rules.category"))
+	registerLang(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.usage.rules"))
+		fs.PrintDefaults()
+	}
+	if _, err := parseArgs(fs, args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitError
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		return fail("err.cli.config", err)
+	}
+	reg, err := cfg.ApplyRules(rules.Default())
+	if err != nil {
+		return fail("err.cli.rules", err)
+	}
+
+	var list []ruleListing
+	for _, info := range report.Rules() {
+		if *category != "" && string(info.Category) != *category {
+			continue
+		}
+		item := ruleListing{RuleInfo: info, Enabled: reg.Enabled(info.ID)}
+		if r, ok := reg.Lookup(info.ID); ok {
+			meta := r.Meta()
+			item.Points = meta.Points
+			if len(meta.Params) > 0 {
+				item.Params = make(map[string]float64, len(meta.Params))
+				for name := range meta.Params {
+					item.Params[name] = reg.Param(info.ID, name)
+				}
+			}
+		}
+		list = append(list, item)
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(list); err != nil {
+			return fail("err.cli.write", err)
+		}
+		return exitOK
+	}
+
+	gray := color.New(color.FgHiBlack).SprintFunc()
+	for _, item := range list {
+		state := color.GreenString("✅")
+		if !item.Enabled {
+			state = color.RedString("⛔")
+		}
+		fmt.Printf("%s %-34s %-8s %-11s %s\n", state, item.ID, item.Severity, item.Category, item.Title)
+		if len(item.Params) > 0 {
+			names := make([]string, 0, len(item.Params))
+			for name := range item.Params {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			parts := make([]string, 0, len(names))
+			for _, name := range names {
+				parts = append(parts, fmt.Sprintf("%s=%g", name, item.Params[name]))
+			}
+			fmt.Printf("   %s\n", gray(strings.Join(parts, ", ")))
+		}
+	}
+	return exitOK
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"bullwler/internal/analyzer"
+	"bullwler/internal/crawler"
+	"bullwler/internal/i18n"
+	"bullwler/internal/report"
+	"bullwler/internal/sitemap"
+)
+
+func runSitemap(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.usage.sitemap"))
+		return exitError
+	}
+	switch args[0] {
+	case "validate":
+		return runSitemapValidate(args[1:])
+	case "generate":
+		return runSitemapGenerate(args[1:])
+	case "help", "-h", "-help", "--help":
+		fmt.Println(i18n.T("cli.usage.sitemap"))
+		return exitOK
+	default:
+		fmt.Fprintln(os.Stderr, i18n.T("cli.usage.sitemap"))
+		return exitError
+	}
+}
+
+// runSitemapValidate — проверяет карту сайта по URL или локальный файл.
+// Код завершения 1 означает, что в картах найдены ошибки.
+func runSitemapValidate(args []string) int {
+	fs := flag.NewFlagSet("sitemap validate", flag.ContinueOnError)
+	var cf commonFlags
+	fs.StringVar(&cf.format, "format", "text", i18n.T("flag.format.text-json"))
+	cf.registerOutput(fs, i18n.T("flag.output.result"))
+	cf.registerHTTP(fs)
+	host := fs.String("host", "", i18n.T("flag.sitemap.host"))
+	registerLang(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.usage.sitemap.validate"))
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseArgs(fs, args)
+	if err == flag.ErrHelp {
+		return exitOK
+	}
+	if err != nil {
+		return exitError
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		return exitError
+	}
+	if cf.format != "text" && cf.format != "json" {
+		return fail("err.cli.format", cf.format)
+	}
+	cfg, err := resolveConfig(fs, &cf, nil)
+	if err != nil {
+		return fail("err.cli.config", err)
+	}
+	if err := setupColor(cf.color, cf.output != ""); err != nil {
+		return fail("%v", err)
+	}
+
+	var files []*sitemap.Validation
+	if target := positional[0]; analyzer.HasScheme(target) {
+		fetcher, err := cfg.Fetcher(target)
+		if err != nil {
+			return fail("err.cli.http", err)
+		}
+		ctx, stop := interruptContext()
+		defer stop()
+		files = sitemap.Check(ctx, fetcher, target)
+		if ctx.Err() != nil {
+			return fail("err.cli.interrupted")
+		}
+	} else {
+		scope, err := hostScope(*host)
+		if err != nil {
+			return fail("%v", err)
+		}
+		data, err := readSitemapFile(target)
+		if err != nil {
+			return fail("err.cli.sitemap.read", err)
+		}
+		files = []*sitemap.Validation{sitemap.Validate(target, data, scope)}
+	}
+
+	result := sitemap.NewResult(files)
+	err = withOutput(cf.output, func(w io.Writer) error {
+		if cf.format == "json" {
+			return result.WriteJSON(w)
+		}
+		result.Fprint(w)
+		return nil
+	})
+	if err != nil {
+		return fail("err.cli.write", err)
+	}
+	if result.Errors > 0 {
+		return exitGateFailed
+	}
+	return exitOK
+}
+
+// hostScope — область URL для локального файла из флага --host: пусто — хост первой записи,
+// "example.com" — любой схемы, "https://example.com" — только https
+func hostScope(host string) (*url.URL, error) {
+	if host == "" {
+		return nil, nil
+	}
+	if !analyzer.HasScheme(host) {
+		host = "//" + host
+	}
+	u, err := url.Parse(host)
+	if err != nil || u.Host == "" {
+		return nil, errors.New(i18n.T("err.cli.sitemap.host", strings.TrimPrefix(host, "//")))
+	}
+	return &url.URL{Scheme: u.Scheme, Host: u.Host}, nil
+}
+
+// readSitemapFile — читает локальную карту сайта; файл больше лимита протокола
+// читается с запасом в байт, чтобы проверка отметила превышение
+func readSitemapFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(io.LimitReader(f, sitemap.MaxFileSize+1))
+}
+
+// runSitemapGenerate — сканирует сайт и записывает карту из индексируемых канонических
+// страниц с кодом 200. Больше sitemap.MaxURLs записей делится на файлы с индексом.
+func runSitemapGenerate(args []string) int {
+	fs := flag.NewFlagSet("sitemap generate", flag.ContinueOnError)
+	var cf commonFlags
+	cf.registerOutput(fs, i18n.T("flag.sitemap.output"))
+	cf.registerHTTP(fs)
+	var crf crawlFlags
+	crf.registerLimits(fs)
+	baseURL := fs.String("base-url", "", i18n.T("flag.sitemap.base-url"))
+	registerLang(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.usage.sitemap.generate"))
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseArgs(fs, args)
+	if err == flag.ErrHelp {
+		return exitOK
+	}
+	if err != nil {
+		return exitError
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		return exitError
+	}
+	cfg, err := resolveConfig(fs, &cf, &crf)
+	if err != nil {
+		return fail("err.cli.config", err)
+	}
+	if err := setupColor(cf.color, cf.output != ""); err != nil {
+		return fail("%v", err)
+	}
+
+	targetURL := normalizeTarget(positional[0])
+	fetcher, err := cfg.Fetcher(targetURL)
+	if err != nil {
+		return fail("err.cli.http", err)
+	}
+	analyzeOpts, reg, err := analyzerOptions(cfg, fetcher)
+	if err != nil {
+		return fail("err.cli.rules", err)
+	}
+	crawlOpts, err := crawlerOptions(cfg, fetcher, reg, analyzeOpts)
+	if err != nil {
+		return fail("err.cli.config", err)
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	site, err := crawler.NewCrawler(crawlOpts...).CrawlSite(ctx, targetURL)
+	if err != nil {
+		return fail("err.cli.crawl", err)
+	}
+	// Карта по прерванному сканированию неполна: лучше не записывать её поверх прежней
+	if site.Stopped == report.StopCanceled {
+		return fail("err.cli.interrupted")
+	}
+	if site.Stopped != "" {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", i18n.T("log.sitemap.partial"))
+	}
+
+	entries := crawler.SitemapEntries(site)
+	if len(entries) <= sitemap.MaxURLs {
+		err = withOutput(cf.output, func(w io.Writer) error {
+			return sitemap.Write(w, entries)
+		})
+		if err != nil {
+			return fail("err.cli.write", err)
+		}
+		if cf.output != "" {
+			fmt.Fprintf(os.Stderr, "🗺️  %s\n", i18n.T("log.sitemap.written", len(entries), cf.output))
+		}
+		return exitOK
+	}
+
+	if cf.output == "" {
+		return fail("err.cli.sitemap.split", len(entries), sitemap.MaxURLs)
+	}
+	base := *baseURL
+	if base == "" {
+		u, err := url.Parse(targetURL)
+		if err != nil {
+			return fail("err.cli.crawl", err)
+		}
+		base = u.Scheme + "://" + u.Host
+	}
+	if err := writeSitemapParts(cf.output, strings.TrimRight(base, "/"), entries); err != nil {
+		return fail("err.cli.write", err)
+	}
+	return exitOK
+}
+
+// writeSitemapParts — делит записи на файлы по sitemap.MaxURLs рядом с path
+// (sitemap.xml → sitemap-1.xml, sitemap-2.xml…) и записывает в path индекс этих файлов
+func writeSitemapParts(path, base string, entries []sitemap.URL) error {
+	ext := filepath.Ext(path)
+	stem := strings.TrimSuffix(path, ext)
+	var index []sitemap.IndexEntry
+	for i := 0; i*sitemap.MaxURLs < len(entries); i++ {
+		part := entries[i*sitemap.MaxURLs : min((i+1)*sitemap.MaxURLs, len(entries))]
+		name := fmt.Sprintf("%s-%d%s", stem, i+1, ext)
+		if err := withOutput(name, func(w io.Writer) error { return sitemap.Write(w, part) }); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "🗺️  %s\n", i18n.T("log.sitemap.written", len(part), name))
+		index = append(index, sitemap.IndexEntry{Loc: base + "/" + filepath.Base(name)})
+	}
+	if err := withOutput(path, func(w io.Writer) error { return sitemap.WriteIndex(w, index) }); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "🗺️  %s\n", i18n.T("log.sitemap.index-written", len(index), path))
+	return nil
+}
@@ -5,6 +5,7 @@ go 1.25.4
 require (
 	github.com/fatih/color v1.18.0
 	golang.org/x/net v0.47.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
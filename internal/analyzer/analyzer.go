@@ -2,18 +2,19 @@ package analyzer
 
 import (
 	"context"
-	"fmt"
-	"io"
 	"net/http"
 	"net/url"
-	"os"
 	"strings"
 	"time"
 
+	"bullwler/internal/fetch"
 	"bullwler/internal/helpers"
 	"bullwler/internal/htmlparser"
+	"bullwler/internal/i18n"
 	"bullwler/internal/report"
+	"bullwler/internal/rules"
 
+	"github.com/temoto/robotstxt"
 	"golang.org/x/net/html"
 )
 
@@ -22,67 +23,78 @@ func HasScheme(u string) bool {
 	return strings.HasPrefix(u, "http://") || strings.HasPrefix(u, "https://")
 }
 
-// AnalyzeURL - функция анализа ресурса по ссылке
-func AnalyzeURL(rawURL string) *report.SEOReport {
-	schemaTypes, err := LoadSchemaTypes()
-	if err != nil {
-		schemaTypes = GetFallbackSchemaTypes()
-		fmt.Fprintf(os.Stderr, "⚠️  Используется fallback-список типов: %v\n", err)
+// Option - функциональная опция анализа страницы
+type Option func(*options)
+
+type options struct {
+	registry *rules.Registry
+	timeout  time.Duration
+	fetcher  *fetch.Fetcher
+}
+
+// WithRules - задаёт реестр правил, по которому проверяется страница
+func WithRules(reg *rules.Registry) Option { return func(o *options) { o.registry = reg } }
+
+// WithTimeout - задаёт таймаут загрузки страницы
+func WithTimeout(d time.Duration) Option { return func(o *options) { o.timeout = d } }
+
+// WithFetcher - задаёт HTTP-клиент для загрузки страницы, robots.txt, sitemap.xml и словаря schema.org
+func WithFetcher(f *fetch.Fetcher) Option { return func(o *options) { o.fetcher = f } }
+
+// AnalyzeURL - функция анализа ресурса по ссылке; отмена ctx прерывает загрузку страницы
+func AnalyzeURL(ctx context.Context, rawURL string, opts ...Option) *report.SEOReport {
+	o := options{
+		registry: rules.Default(),
+		timeout:  15 * time.Second,
+		fetcher:  fetch.Default(),
+	}
+	for _, opt := range opts {
+		opt(&o)
 	}
 
-	rep := report.New(rawURL, schemaTypes)
+	rep := report.New(rawURL, schemaTypes(ctx, o.fetcher))
+	rep.Rules = o.registry.Catalog()
 
 	base, err := url.Parse(rawURL)
 	if err != nil {
-		rep.Errors = append(rep.Errors, "Некорректный URL")
+		rep.AddFinding("network.url.invalid", i18n.T("msg.network.url.invalid"), nil)
 		return rep
 	}
 
-	rep.HasRobotsTxt = helpers.CheckResourceExists(base.Scheme + "://" + base.Host + "/robots.txt")
-	rep.HasSitemap = helpers.CheckResourceExists(base.Scheme + "://" + base.Host + "/sitemap.xml")
+	var sitemaps []string
+	rep.HasRobotsTxt, sitemaps = robotsSitemaps(ctx, o.fetcher, base.Scheme+"://"+base.Host+"/robots.txt")
+	rep.HasSitemap = len(sitemaps) > 0 || resourceExists(ctx, o.fetcher, base.Scheme+"://"+base.Host+"/sitemap.xml")
 
-	client := &http.Client{
-		Timeout: 15 * time.Second,
-		CheckRedirect: func(req *http.Request, _ []*http.Request) error {
-			rep.Redirects = append(rep.Redirects, req.URL.String())
-			return nil
-		},
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
 	defer cancel()
 
-	req, _ := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; Bullwler/1.0)")
-
-	start := time.Now()
-	resp, err := client.Do(req)
-	if err != nil {
-		rep.Errors = append(rep.Errors, "Не удалось загрузить страницу: "+err.Error())
+	resp, err := o.fetcher.Get(ctx, rawURL)
+	if resp == nil {
+		rep.AddFinding("network.fetch.failed", i18n.T("msg.network.fetch.failed", err), nil)
 		return rep
 	}
-	defer resp.Body.Close()
 
+	rep.Redirects = resp.Redirects
 	rep.StatusCode = resp.StatusCode
-	rep.ResponseTimeMs = time.Since(start).Milliseconds()
-
-	// Security headers
-	rep.MissingSecurityHeaders = checkSecurityHeaders(resp.Header, rep.IsHTTPS)
+	rep.ResponseTimeMs = resp.Elapsed.Milliseconds()
+	rep.LastModified = resp.Header.Get("Last-Modified")
 
 	if resp.StatusCode != 200 {
-		rep.Warnings = append(rep.Warnings, fmt.Sprintf("HTTP статус: %d", resp.StatusCode))
+		rep.AddFinding("network.status.not-ok", i18n.T("msg.network.status.not-ok", resp.StatusCode), &report.Evidence{Snippet: resp.Status})
 	}
 
-	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		rep.Errors = append(rep.Errors, "Ошибка чтения тела")
+		rep.AddFinding("network.body.unreadable", i18n.T("msg.network.body.unreadable"), nil)
 		return rep
 	}
+	if resp.Truncated {
+		rep.AddFinding("network.body.too-large", i18n.T("msg.network.body.too-large", len(resp.Data)), nil)
+	}
 
-	htmlStr := string(body)
+	htmlStr := string(resp.Data)
 	doc, err := html.Parse(strings.NewReader(htmlStr))
 	if err != nil {
-		rep.Errors = append(rep.Errors, "Ошибка парсинга HTML")
+		rep.AddFinding("network.html.unparsable", i18n.T("msg.network.html.unparsable"), nil)
 		return rep
 	}
 
@@ -97,56 +109,55 @@ func AnalyzeURL(rawURL string) *report.SEOReport {
 	htmlparser.AnalyzeNode(doc, rep, labelForMap)
 	htmlparser.CheckAIDeepFeatures(rep)
 
+	rep.XRobotsTag = resp.Header.Get("X-Robots-Tag")
+	rep.Noindex = hasNoindex(rep.MetaRobots) || hasNoindex(rep.XRobotsTag)
+
 	rep.TitleLength = len(rep.Title)
 	rep.DescriptionLength = len(rep.Description)
 	rep.HeadingsValid = htmlparser.ValidateHeadings(rep)
 
 	htmlparser.CheckAIFeatures(rep)
-	htmlparser.AddWarnings(rep)
+	o.registry.Run(doc, resp.Response, rep)
 
 	return rep
 }
 
-func checkSecurityHeaders(headers http.Header, isHTTPS bool) []string {
-	var missing []string
-
-	if headers.Get("Content-Security-Policy") == "" {
-		missing = append(missing, "Content-Security-Policy")
-	}
-	if headers.Get("X-Frame-Options") == "" {
-		missing = append(missing, "X-Frame-Options")
-	}
-	if headers.Get("X-Content-Type-Options") != "nosniff" {
-		missing = append(missing, "X-Content-Type-Options")
-	}
-	if isHTTPS && headers.Get("Strict-Transport-Security") == "" {
-		missing = append(missing, "Strict-Transport-Security")
-	}
-
-	if headers.Get("Referrer-Policy") == "" {
-		missing = append(missing, "Referrer-Policy")
-	}
-	if headers.Get("Permissions-Policy") == "" {
-		missing = append(missing, "Permissions-Policy")
-	}
+// hasNoindex - проверяет, запрещают ли директивы robots индексацию страницы
+func hasNoindex(directives string) bool {
+	for _, d := range strings.Split(directives, ",") {
+		d = strings.ToLower(strings.TrimSpace(d))
+		// В X-Robots-Tag директива может быть адресована конкретному краулеру: "googlebot: noindex"
+		if i := strings.LastIndex(d, ":"); i >= 0 {
+			d = strings.TrimSpace(d[i+1:])
+		}
+		if d == "noindex" || d == "none" {
+			return true
+		}
+	}
+	return false
+}
 
-	if headers.Get("Cross-Origin-Opener-Policy") == "" {
-		missing = append(missing, "Cross-Origin-Opener-Policy")
-	}
-	if headers.Get("Cross-Origin-Embedder-Policy") == "" {
-		missing = append(missing, "Cross-Origin-Embedder-Policy")
-	}
+// resourceTimeout - таймаут проверки доступности robots.txt и sitemap.xml
+const resourceTimeout = 5 * time.Second
 
-	if headers.Get("Server") != "" {
-		missing = append(missing, "Server (рекомендуется убрать)")
-	}
-	if headers.Get("X-Powered-By") != "" {
-		missing = append(missing, "X-Powered-By (рекомендуется убрать)")
+// robotsSitemaps - проверяет наличие robots.txt и возвращает адреса карт сайта из его директив Sitemap
+func robotsSitemaps(ctx context.Context, f *fetch.Fetcher, u string) (bool, []string) {
+	ctx, cancel := context.WithTimeout(ctx, resourceTimeout)
+	defer cancel()
+	resp, err := f.Get(ctx, u)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return false, nil
 	}
-
-	if val := headers.Get("X-XSS-Protection"); val != "" && val != "0" {
-		missing = append(missing, "X-XSS-Protection (рекомендуется: 0 или отсутствие)")
+	robots, err := robotstxt.FromStatusAndBytes(resp.StatusCode, resp.Data)
+	if err != nil {
+		return true, nil
 	}
+	return true, robots.Sitemaps
+}
 
-	return missing
+// resourceExists - проверяет, что служебный ресурс сайта отвечает кодом 200
+func resourceExists(ctx context.Context, f *fetch.Fetcher, u string) bool {
+	ctx, cancel := context.WithTimeout(ctx, resourceTimeout)
+	defer cancel()
+	return f.Exists(ctx, u)
 }
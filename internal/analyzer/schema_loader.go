@@ -1,12 +1,18 @@
 package analyzer
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"time"
+
+	"bullwler/internal/fetch"
+	"bullwler/internal/i18n"
 )
 
 const (
@@ -15,8 +21,37 @@ const (
 	maxAgeHours = 24
 )
 
+// schemaTimeout - таймаут загрузки словаря schema.org
+const schemaTimeout = 30 * time.Second
+
+var (
+	schemaMu     sync.Mutex
+	schemaCached map[string]bool
+)
+
+// schemaTypes - словарь schema.org для проверки страниц. Загружается один раз за процесс;
+// при ошибке загрузки используется фоллбэк, а предупреждение выводится однократно.
+func schemaTypes(ctx context.Context, f *fetch.Fetcher) map[string]bool {
+	schemaMu.Lock()
+	defer schemaMu.Unlock()
+	if schemaCached != nil {
+		return schemaCached
+	}
+
+	types, err := LoadSchemaTypes(ctx, f)
+	if err != nil {
+		if ctx.Err() != nil {
+			return GetFallbackSchemaTypes()
+		}
+		types = GetFallbackSchemaTypes()
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", i18n.T("log.schema.fallback", err))
+	}
+	schemaCached = types
+	return types
+}
+
 // LoadSchemaTypes - функция загрузки типов schema.org в файл
-func LoadSchemaTypes() (map[string]bool, error) {
+func LoadSchemaTypes(ctx context.Context, f *fetch.Fetcher) (map[string]bool, error) {
 	if info, err := os.Stat(schemaFile); err == nil {
 		if time.Since(info.ModTime()) < maxAgeHours*time.Hour {
 			data, err := os.ReadFile(schemaFile)
@@ -31,22 +66,28 @@ func LoadSchemaTypes() (map[string]bool, error) {
 		}
 	}
 
-	fmt.Fprintf(os.Stderr, "⏳ Загрузка актуальных типов Schema.org...\n")
-	resp, err := http.Get(schemaURL)
+	fmt.Fprintf(os.Stderr, "⏳ %s\n", i18n.T("log.schema.loading"))
+	ctx, cancel := context.WithTimeout(ctx, schemaTimeout)
+	defer cancel()
+	req, err := f.NewRequest(ctx, http.MethodGet, schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := f.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("не удалось скачать schema.org: %w", err)
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.schema.download"), err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("ошибка HTTP %d", resp.StatusCode)
+		return nil, errors.New(i18n.T("err.http-status", resp.StatusCode))
 	}
 
 	var container struct {
 		Graph []json.RawMessage `json:"@graph"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&container); err != nil {
-		return nil, fmt.Errorf("ошибка парсинга корневого JSON: %w", err)
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.schema.parse"), err)
 	}
 
 	types := make(map[string]bool)
@@ -73,7 +114,7 @@ func LoadSchemaTypes() (map[string]bool, error) {
 
 	data, _ := json.Marshal(types)
 	os.WriteFile(schemaFile, data, 0644)
-	fmt.Fprintf(os.Stderr, "✅ Загружено %d типов Schema.org\n", len(types))
+	fmt.Fprintf(os.Stderr, "✅ %s\n", i18n.T("log.schema.loaded", len(types)))
 	return types, nil
 }
 
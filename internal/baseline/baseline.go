@@ -0,0 +1,155 @@
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"bullwler/internal/i18n"
+	"bullwler/internal/report"
+)
+
+// DefaultFile — файл базовой линии по умолчанию
+const DefaultFile = "bullwler-baseline.json"
+
+const formatVersion = 1
+
+// Entry — известное замечание, сохранённое в базовой линии
+type Entry struct {
+	Fingerprint string `json:"fingerprint"`
+	URL         string `json:"url"`
+	RuleID      string `json:"rule_id"`
+	Evidence    string `json:"evidence,omitempty"`
+	Count       int    `json:"count"`
+}
+
+// Baseline — набор отпечатков известных замечаний
+type Baseline struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Entries   []Entry   `json:"entries"`
+
+	index map[string]int
+}
+
+// Fingerprint — отпечаток замечания: URL страницы, правило и подтверждающий фрагмент
+func Fingerprint(pageURL string, f report.Finding) string {
+	h := sha256.New()
+	h.Write([]byte(pageURL + "\x00" + f.RuleID + "\x00" + evidenceKey(f.Evidence)))
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
+
+func evidenceKey(ev *report.Evidence) string {
+	if ev == nil {
+		return ""
+	}
+	return ev.Element + "|" + ev.Attribute + "|" + ev.Snippet
+}
+
+// New — строит базовую линию по отчётам страниц
+func New(pages []*report.SEOReport) *Baseline {
+	b := &Baseline{Version: formatVersion, CreatedAt: time.Now().UTC(), index: make(map[string]int)}
+	for _, p := range pages {
+		for _, f := range p.Findings {
+			fp := Fingerprint(p.URL, f)
+			if i, ok := b.index[fp]; ok {
+				b.Entries[i].Count++
+				continue
+			}
+			b.index[fp] = len(b.Entries)
+			b.Entries = append(b.Entries, Entry{
+				Fingerprint: fp,
+				URL:         p.URL,
+				RuleID:      f.RuleID,
+				Evidence:    evidenceKey(f.Evidence),
+				Count:       1,
+			})
+		}
+	}
+	sort.Slice(b.Entries, func(i, j int) bool {
+		if b.Entries[i].URL != b.Entries[j].URL {
+			return b.Entries[i].URL < b.Entries[j].URL
+		}
+		return b.Entries[i].RuleID < b.Entries[j].RuleID
+	})
+	b.reindex()
+	return b
+}
+
+func (b *Baseline) reindex() {
+	b.index = make(map[string]int, len(b.Entries))
+	for i, e := range b.Entries {
+		b.index[e.Fingerprint] = i
+	}
+}
+
+// Load — читает базовую линию из файла
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.baseline.read"), err)
+	}
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.baseline.parse", path), err)
+	}
+	if b.Version != formatVersion {
+		return nil, errors.New(i18n.T("err.baseline.version", b.Version))
+	}
+	b.reindex()
+	return &b, nil
+}
+
+// Save — записывает базовую линию в файл
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Apply — удаляет из отчётов известные замечания, оставляя только новые,
+// и возвращает сводку сравнения. Исправленными считаются только замечания
+// страниц, проанализированных в текущем запуске.
+func (b *Baseline) Apply(pages []*report.SEOReport) *report.BaselineDelta {
+	delta := &report.BaselineDelta{Fixed: []report.FixedFinding{}}
+	remaining := make(map[string]int, len(b.Entries))
+	for _, e := range b.Entries {
+		remaining[e.Fingerprint] = e.Count
+	}
+	checked := make(map[string]bool)
+
+	for _, p := range pages {
+		checked[p.URL] = true
+		kept := p.Findings[:0]
+		for _, f := range p.Findings {
+			fp := Fingerprint(p.URL, f)
+			if remaining[fp] > 0 {
+				remaining[fp]--
+				delta.Suppressed++
+				continue
+			}
+			delta.New++
+			kept = append(kept, f)
+		}
+		p.Findings = kept
+	}
+
+	for _, e := range b.Entries {
+		if n := remaining[e.Fingerprint]; n > 0 && checked[e.URL] {
+			delta.Fixed = append(delta.Fixed, report.FixedFinding{
+				URL:      e.URL,
+				RuleID:   e.RuleID,
+				Evidence: e.Evidence,
+				Count:    n,
+			})
+		}
+	}
+	return delta
+}
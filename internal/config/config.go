@@ -0,0 +1,352 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"bullwler/internal/crawler"
+	"bullwler/internal/fetch"
+	"bullwler/internal/i18n"
+	"bullwler/internal/rules"
+)
+
+// DefaultFiles — имена файлов конфигурации, которые ищутся в рабочей директории, в порядке приоритета
+var DefaultFiles = []string{"bullwler.json", ".bullwler.json", "bullwler.yaml", ".bullwler.yaml", "bullwler.yml", ".bullwler.yml"}
+
+// Config — профиль аудита проекта
+type Config struct {
+	Crawler CrawlerConfig `json:"crawler"`
+	// UserAgent — имя пресета краулера (googlebot-smartphone, gptbot и т. д.) или строка User-Agent;
+	// определяет и заголовок запросов, и группу правил robots.txt
+	UserAgent string      `json:"user_agent"`
+	Timeouts  Timeouts    `json:"timeouts"`
+	Rules     RulesConfig `json:"rules"`
+	Include   []string    `json:"include"`
+	Exclude   []string    `json:"exclude"`
+	// IncludeGlob, ExcludeGlob — те же фильтры в виде glob-шаблонов ("/blog/**", "**/tag/**")
+	IncludeGlob []string     `json:"include_glob"`
+	ExcludeGlob []string     `json:"exclude_glob"`
+	Output      OutputConfig `json:"output"`
+	FailOn      []string     `json:"fail_on"`
+	// BaselineFile — файл базовой линии известных замечаний
+	BaselineFile string        `json:"baseline_file"`
+	History      HistoryConfig `json:"history"`
+	HTTP         HTTPConfig    `json:"http"`
+	// Lang — язык сообщений: en, ru или путь к JSON-каталогу; пусто — по переменным окружения
+	Lang string `json:"lang"`
+
+	// Path — файл, из которого загружена конфигурация (пусто для значений по умолчанию)
+	Path string `json:"-"`
+}
+
+// CrawlerConfig — ограничения краулера
+type CrawlerConfig struct {
+	MaxDepth    int `json:"max_depth"`
+	MaxPages    int `json:"max_pages"`
+	Concurrency int `json:"concurrency"`
+	// Sitemaps — брать точки входа из карт сайта и проверять покрытие сайта картами
+	Sitemaps bool `json:"sitemaps"`
+	// StateDir — директория, в которую по ходу сканирования сохраняются очередь и результаты;
+	// пусто — состояние не сохраняется
+	StateDir string `json:"state_dir"`
+	// Resume — продолжить сканирование из StateDir; задаётся только флагом --resume
+	Resume bool `json:"-"`
+	// PathPrefixes — разделы сайта ("/blog/"), по ссылкам в которые идёт сканирование; пусто — весь сайт
+	PathPrefixes []string `json:"path_prefixes"`
+	// Subdomains — считать внутренними ссылки на поддомены стартового хоста
+	Subdomains bool `json:"subdomains"`
+	// AllowedHosts — дополнительные внутренние хосты; "*.example.com" — домен и его поддомены
+	AllowedHosts []string `json:"allowed_hosts"`
+	// SectionPages — лимит страниц одного раздела (первого сегмента пути); 0 — без ограничения
+	SectionPages int `json:"section_pages"`
+}
+
+// Timeouts — таймауты загрузки
+type Timeouts struct {
+	// Page — таймаут загрузки одной страницы
+	Page Duration `json:"page"`
+	// Crawl — общий лимит времени на сканирование сайта; 0 — без ограничения
+	Crawl Duration `json:"crawl"`
+}
+
+// RulesConfig — включение правил и переопределение порогов
+type RulesConfig struct {
+	Disable    []string                      `json:"disable"`
+	Enable     []string                      `json:"enable"`
+	Thresholds map[string]map[string]float64 `json:"thresholds"`
+}
+
+// OutputConfig — параметры вывода
+type OutputConfig struct {
+	Format string `json:"format"`
+}
+
+// HistoryConfig — локальная история аудитов сайта
+type HistoryConfig struct {
+	// Enabled — сохранять снимок каждого сканирования сайта
+	Enabled bool `json:"enabled"`
+	// Dir — директория снимков
+	Dir string `json:"dir"`
+}
+
+// HTTPConfig — параметры HTTP-клиента, общие для загрузки страниц, robots.txt и sitemap.xml
+type HTTPConfig struct {
+	// Headers — заголовки, например Authorization, добавляемые только к запросам на проверяемые хосты:
+	// стартовый, его поддомены при crawler.subdomains и crawler.allowed_hosts. При перенаправлении
+	// на другой хост они удаляются, поэтому третьим лицам не отправляются.
+	Headers map[string]string `json:"headers"`
+	// Cookies — cookie вида "name=value"; отправляются, как и Headers, только проверяемым хостам
+	Cookies []string `json:"cookies"`
+	// Proxy — прокси-сервер; пусто — по переменным окружения HTTP_PROXY и HTTPS_PROXY
+	Proxy string `json:"proxy"`
+	// CAFiles — PEM-файлы дополнительных корневых сертификатов
+	CAFiles []string `json:"ca_files"`
+	// MaxBodySize — ограничение размера тела ответа в байтах; 0 — без ограничения
+	MaxBodySize int64 `json:"max_body_size"`
+	// MaxConnsPerHost — размер пула соединений с одним хостом
+	MaxConnsPerHost int `json:"max_conns_per_host"`
+}
+
+// Duration — длительность, задаваемая в конфигурации строкой ("15s", "2m")
+type Duration time.Duration
+
+// UnmarshalJSON — разбирает длительность из строки или числа секунд
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		v, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("%s: %w", i18n.T("err.config.duration.invalid", s), err)
+		}
+		*d = Duration(v)
+		return nil
+	}
+	var secs float64
+	if err := json.Unmarshal(data, &secs); err != nil {
+		return errors.New(i18n.T("err.config.duration.type"))
+	}
+	*d = Duration(secs * float64(time.Second))
+	return nil
+}
+
+// MarshalJSON — сериализует длительность строкой
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Default — возвращает конфигурацию по умолчанию
+func Default() *Config {
+	return &Config{
+		Crawler: CrawlerConfig{
+			MaxDepth:    3,
+			MaxPages:    30,
+			Concurrency: 5,
+			Sitemaps:    true,
+		},
+		Timeouts: Timeouts{
+			Page: Duration(15 * time.Second),
+		},
+		HTTP:         HTTPConfig{MaxBodySize: fetch.DefaultMaxBodySize},
+		Output:       OutputConfig{Format: "text"},
+		BaselineFile: "bullwler-baseline.json",
+		History:      HistoryConfig{Dir: ".bullwler/history"},
+	}
+}
+
+// Load — читает конфигурацию из файла поверх значений по умолчанию.
+// Файлы .yaml и .yml разбираются как YAML с той же схемой, что и JSON.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.config.read"), err)
+	}
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+		if data, err = yamlToJSON(data); err != nil {
+			return nil, fmt.Errorf("%s: %w", i18n.T("err.config.parse", path), err)
+		}
+	}
+	cfg := Default()
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.config.parse", path), err)
+	}
+	cfg.Path = path
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// yamlToJSON — переводит YAML-документ в JSON, чтобы он разбирался по тем же тегам
+// и правилам (длительности, пороги), что и конфигурация в JSON
+func yamlToJSON(data []byte) ([]byte, error) {
+	var v any
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonValue(v))
+}
+
+// jsonValue — приводит ключи вложенных YAML-словарей к строкам
+func jsonValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			v[k] = jsonValue(item)
+		}
+		return v
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, item := range v {
+			m[fmt.Sprint(k)] = jsonValue(item)
+		}
+		return m
+	case []any:
+		for i, item := range v {
+			v[i] = jsonValue(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// Discover — ищет файл конфигурации в директории dir по списку DefaultFiles.
+// Возвращает конфигурацию по умолчанию, если файл не найден.
+func Discover(dir string) (*Config, error) {
+	for _, name := range DefaultFiles {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return Load(path)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return Default(), nil
+}
+
+// Validate — проверяет значения конфигурации
+func (c *Config) Validate() error {
+	if c.Crawler.MaxDepth < 0 {
+		return errors.New(i18n.T("err.config.max-depth"))
+	}
+	if c.Crawler.MaxPages < 1 {
+		return errors.New(i18n.T("err.config.max-pages"))
+	}
+	if c.Crawler.Concurrency < 1 {
+		return errors.New(i18n.T("err.config.concurrency"))
+	}
+	if c.Timeouts.Page <= 0 || c.Timeouts.Crawl < 0 {
+		return errors.New(i18n.T("err.config.timeouts"))
+	}
+	if c.HTTP.MaxBodySize < 0 || c.HTTP.MaxConnsPerHost < 0 {
+		return errors.New(i18n.T("err.config.http-limits"))
+	}
+	if c.History.Dir == "" {
+		return errors.New(i18n.T("err.config.history-dir"))
+	}
+	if c.Crawler.Resume && c.Crawler.StateDir == "" {
+		return errors.New(i18n.T("err.config.resume"))
+	}
+	if c.Crawler.SectionPages < 0 {
+		return errors.New(i18n.T("err.config.section-pages"))
+	}
+	for _, h := range c.Crawler.AllowedHosts {
+		if strings.TrimSpace(h) == "" || strings.ContainsAny(h, "/:") {
+			return errors.New(i18n.T("err.config.allowed-host", h))
+		}
+	}
+	if _, _, err := c.Patterns(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Patterns — компилирует шаблоны include/exclude; glob-шаблоны переводятся в регулярные выражения
+// и добавляются к ним
+func (c *Config) Patterns() (include, exclude []*regexp.Regexp, err error) {
+	compile := func(patterns, globs []string) ([]*regexp.Regexp, error) {
+		var out []*regexp.Regexp
+		for _, p := range patterns {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", i18n.T("err.config.pattern", p), err)
+			}
+			out = append(out, re)
+		}
+		for _, g := range globs {
+			re, err := crawler.CompileGlob(g)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, re)
+		}
+		return out, nil
+	}
+	if include, err = compile(c.Include, c.IncludeGlob); err != nil {
+		return nil, nil, err
+	}
+	if exclude, err = compile(c.Exclude, c.ExcludeGlob); err != nil {
+		return nil, nil, err
+	}
+	return include, exclude, nil
+}
+
+// Fetcher — создаёт HTTP-клиент с заданными в конфигурации User-Agent, заголовками,
+// cookie, прокси и сертификатами. Заголовки и cookie отправляются только на хосты
+// проверки target: её хост, поддомены при crawler.subdomains и crawler.allowed_hosts.
+func (c *Config) Fetcher(target string) (*fetch.Fetcher, error) {
+	opts := []fetch.Option{
+		fetch.WithUserAgent(c.UserAgent),
+		fetch.WithCredentialHosts(crawler.ScopeHosts(target, c.Crawler.Subdomains, c.Crawler.AllowedHosts)...),
+		fetch.WithProxy(c.HTTP.Proxy),
+		fetch.WithMaxBodySize(c.HTTP.MaxBodySize),
+		fetch.WithMaxConnsPerHost(c.HTTP.MaxConnsPerHost),
+	}
+	names := make([]string, 0, len(c.HTTP.Headers))
+	for name := range c.HTTP.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		opts = append(opts, fetch.WithHeader(name, c.HTTP.Headers[name]))
+	}
+	for _, raw := range c.HTTP.Cookies {
+		opts = append(opts, fetch.WithCookie(raw))
+	}
+	for _, path := range c.HTTP.CAFiles {
+		opts = append(opts, fetch.WithCAFile(path))
+	}
+	return fetch.New(opts...)
+}
+
+// ApplyRules — возвращает копию реестра с применёнными настройками правил
+func (c *Config) ApplyRules(base *rules.Registry) (*rules.Registry, error) {
+	reg := base.Clone()
+	for _, id := range c.Rules.Disable {
+		if err := reg.SetEnabled(id, false); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range c.Rules.Enable {
+		if err := reg.SetEnabled(id, true); err != nil {
+			return nil, err
+		}
+	}
+	for id, params := range c.Rules.Thresholds {
+		for name, v := range params {
+			if err := reg.SetParam(id, name, v); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return reg, nil
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const jsonConfig = `{
+  "crawler": { "max_depth": 4, "max_pages": 120, "path_prefixes": ["/blog/"] },
+  "user_agent": "gptbot",
+  "timeouts": { "page": "20s", "crawl": 90 },
+  "http": { "headers": { "Authorization": "Basic dXNlcjpwYXNz" }, "cookies": ["preview=1"] },
+  "rules": {
+    "disable": ["seo.twitter.missing"],
+    "thresholds": { "seo.title.too-long": { "max_length": 70 } }
+  },
+  "exclude_glob": ["**/tag/**"],
+  "fail_on": ["error"]
+}`
+
+const yamlConfig = `
+crawler:
+  max_depth: 4
+  max_pages: 120
+  path_prefixes: [/blog/]
+user_agent: gptbot
+timeouts:
+  page: 20s
+  crawl: 90
+http:
+  headers:
+    Authorization: Basic dXNlcjpwYXNz
+  cookies:
+    - preview=1
+rules:
+  disable:
+    - seo.twitter.missing
+  thresholds:
+    seo.title.too-long:
+      max_length: 70
+exclude_glob: ["**/tag/**"]
+fail_on: [error]
+`
+
+func writeFile(t *testing.T, dir, name, data string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadYAMLMatchesJSON(t *testing.T) {
+	dir := t.TempDir()
+	fromJSON, err := Load(writeFile(t, dir, "bullwler.json", jsonConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"bullwler.yaml", ".bullwler.yml"} {
+		fromYAML, err := Load(writeFile(t, dir, name, yamlConfig))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if time.Duration(fromYAML.Timeouts.Page) != 20*time.Second || time.Duration(fromYAML.Timeouts.Crawl) != 90*time.Second {
+			t.Errorf("%s: timeouts = %+v", name, fromYAML.Timeouts)
+		}
+		fromYAML.Path = fromJSON.Path
+		if !reflect.DeepEqual(fromYAML, fromJSON) {
+			t.Errorf("%s: config differs from JSON:\n got %+v\nwant %+v", name, fromYAML, fromJSON)
+		}
+	}
+}
+
+func TestLoadYAMLErrors(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string]string{
+		"syntax.yaml":   "crawler: [max_pages",
+		"duration.yaml": "timeouts:\n  page: soon\n",
+		"invalid.yaml":  "crawler:\n  max_pages: 0\n",
+	} {
+		if _, err := Load(writeFile(t, dir, name, data)); err == nil {
+			t.Errorf("%s: want error, got nil", name)
+		}
+	}
+}
+
+func TestDiscoverFindsYAML(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := writeFile(t, dir, ".bullwler.yaml", "crawler:\n  max_pages: 7\n")
+	cfg, err := Discover(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Path != yamlPath || cfg.Crawler.MaxPages != 7 {
+		t.Errorf("got Path=%q max_pages=%d, want .bullwler.yaml with 7", cfg.Path, cfg.Crawler.MaxPages)
+	}
+
+	// JSON-файл приоритетнее YAML
+	jsonPath := writeFile(t, dir, "bullwler.json", `{"crawler": {"max_pages": 9}}`)
+	if cfg, err = Discover(dir); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Path != jsonPath || cfg.Crawler.MaxPages != 9 {
+		t.Errorf("got Path=%q max_pages=%d, want bullwler.json with 9", cfg.Path, cfg.Crawler.MaxPages)
+	}
+}
@@ -2,9 +2,11 @@ package crawler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/url"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -12,26 +14,45 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"bullwler/internal/analyzer"
+	"bullwler/internal/events"
+	"bullwler/internal/fetch"
+	"bullwler/internal/i18n"
 	"bullwler/internal/report"
+	"bullwler/internal/rules"
+	"bullwler/internal/sitemap"
 )
 
 // Crawler — структура краулера
 type Crawler struct {
 	robots      *RobotsClient
+	fetcher     *fetch.Fetcher
 	maxDepth    int
 	maxPages    int
 	concurrency int
-	userAgent   string
+	timeout     time.Duration
+	include     []*regexp.Regexp
+	exclude     []*regexp.Regexp
+	prefixes    []string
+	subdomains  bool
+	hosts       []string
+	sectionMax  int
+	analyzeOpts []analyzer.Option
+	rules       *rules.Registry
+	onEvent     events.Handler
+	sitemaps    bool
+	stateDir    string
+	resume      bool
 }
 
 // NewCrawler — создаёт новый инстанс краулера
 func NewCrawler(opts ...Option) *Crawler {
 	c := &Crawler{
 		robots:      NewRobotsClient(),
+		fetcher:     fetch.Default(),
 		maxDepth:    2,
 		maxPages:    30,
 		concurrency: 5,
-		userAgent:   "BullwlerBot/1.0",
+		sitemaps:    true,
 	}
 	for _, opt := range opts {
 		opt(c)
@@ -51,135 +72,372 @@ func WithMaxPages(n int) Option { return func(c *Crawler) { c.maxPages = n } }
 // WithConcurrency — задаёт количество параллельных горутин
 func WithConcurrency(n int) Option { return func(c *Crawler) { c.concurrency = n } }
 
+// WithTimeout — задаёт общий лимит времени на сканирование; 0 — без ограничения
+func WithTimeout(d time.Duration) Option { return func(c *Crawler) { c.timeout = d } }
+
+// WithURLFilter — задаёт шаблоны URL: при непустом include ссылка должна совпасть
+// хотя бы с одним из них, совпадение с exclude исключает ссылку
+func WithURLFilter(include, exclude []*regexp.Regexp) Option {
+	return func(c *Crawler) {
+		c.include = include
+		c.exclude = exclude
+	}
+}
+
+// WithPathPrefixes — ограничивает сканирование разделами сайта: переходы выполняются
+// только по ссылкам, путь которых лежит в одном из префиксов ("/blog/", "/docs").
+// Стартовая страница сканируется независимо от префиксов.
+func WithPathPrefixes(prefixes ...string) Option {
+	return func(c *Crawler) { c.prefixes = append(c.prefixes, prefixes...) }
+}
+
+// WithSubdomains — считает внутренними ссылки на поддомены стартового хоста
+// (для www.example.com — на example.com и *.example.com)
+func WithSubdomains(enabled bool) Option { return func(c *Crawler) { c.subdomains = enabled } }
+
+// WithAllowedHosts — добавляет хосты, ссылки на которые считаются внутренними;
+// запись "*.example.com" разрешает домен и все его поддомены
+func WithAllowedHosts(hosts ...string) Option {
+	return func(c *Crawler) { c.hosts = append(c.hosts, hosts...) }
+}
+
+// WithSectionBudget — ограничивает число страниц одного раздела: хоста и первого сегмента пути
+// после префикса сканирования; 0 — без ограничения
+func WithSectionBudget(n int) Option { return func(c *Crawler) { c.sectionMax = n } }
+
+// WithFetcher — задаёт HTTP-клиент для загрузки robots.txt и страниц сайта;
+// его идентичность определяет и User-Agent запросов, и группу правил robots.txt
+func WithFetcher(f *fetch.Fetcher) Option {
+	return func(c *Crawler) {
+		c.fetcher = f
+		c.robots.fetcher = f
+		c.analyzeOpts = append(c.analyzeOpts, analyzer.WithFetcher(f))
+	}
+}
+
+// WithSitemaps — включает загрузку карт сайта: их записи становятся точками входа сканирования,
+// а в сводный отчёт добавляется покрытие сайта картами (по умолчанию включено)
+func WithSitemaps(enabled bool) Option { return func(c *Crawler) { c.sitemaps = enabled } }
+
+// WithState — сохраняет очередь и результаты сканирования в директорию dir по ходу работы.
+// С resume сканирование продолжается с сохранённого места: просканированные страницы
+// не загружаются повторно и попадают в отчёт, очередь восстанавливается.
+func WithState(dir string, resume bool) Option {
+	return func(c *Crawler) {
+		c.stateDir = dir
+		c.resume = resume
+	}
+}
+
+// WithAnalyzerOptions — задаёт опции анализа каждой страницы
+func WithAnalyzerOptions(opts ...analyzer.Option) Option {
+	return func(c *Crawler) { c.analyzeOpts = append(c.analyzeOpts, opts...) }
+}
+
+// WithRules — задаёт реестр правил: он передаётся анализатору страниц, а выключенные в нём
+// правила покрытия картой сайта (seo.sitemap.*) не добавляют замечаний в отчёты страниц
+func WithRules(reg *rules.Registry) Option {
+	return func(c *Crawler) {
+		c.rules = reg
+		c.analyzeOpts = append(c.analyzeOpts, analyzer.WithRules(reg))
+	}
+}
+
+// WithEvents — задаёт получателя событий сканирования (начало, завершение и пропуск страниц, итог).
+// Обработчик вызывается из рабочих горутин и должен быть потокобезопасным.
+func WithEvents(h events.Handler) Option { return func(c *Crawler) { c.onEvent = h } }
+
+func (c *Crawler) emit(e events.Event) {
+	if c.onEvent != nil {
+		c.onEvent(e)
+	}
+}
+
 type crawlTask struct {
-	URL   string
-	Depth int
+	URL   string `json:"url"`
+	Depth int    `json:"depth"`
+	// pos — позиция задачи в её уровне очереди, задаёт порядок найденных на странице ссылок
+	pos int
+}
+
+// Crawl — рекурсивно сканирует сайт; отмена ctx завершает сканирование досрочно,
+// при этом возвращаются результаты по уже проанализированным страницам
+func (c *Crawler) Crawl(ctx context.Context, startURL string) ([]report.CrawlResult, error) {
+	out, err := c.crawl(ctx, startURL)
+	if out == nil {
+		return nil, err
+	}
+	return out.results, err
 }
 
-// Crawl — рекурсивно сканирует сайт
-func (c *Crawler) Crawl(startURL string) ([]report.CrawlResult, error) {
+// crawlOutcome — итог сканирования
+type crawlOutcome struct {
+	results []report.CrawlResult
+	// stopped — причина досрочной остановки (пусто, если очередь исчерпана)
+	stopped string
+	// truncated — часть найденных ссылок не просканирована из-за лимитов страниц или глубины
+	truncated bool
+	// linked — нормализованные внутренние URL, на которые ведут ссылки с просканированных страниц
+	linked map[string]bool
+	// sitemap — карты сайта, записи которых добавлены в очередь; nil, если карты не загружались
+	sitemap *sitemap.Set
+	// scope — границы сканирования, по которым отбирались ссылки
+	scope *scope
+}
+
+// crawl — сканирует сайт, начиная со startURL и записей карт сайта
+func (c *Crawler) crawl(ctx context.Context, startURL string) (*crawlOutcome, error) {
 	base, err := url.Parse(startURL)
 	if err != nil {
-		return nil, fmt.Errorf("некорректный стартовый URL: %w", err)
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.crawl.start-url"), err)
+	}
+	sc := c.newScope(base)
+	started := time.Now()
+
+	var cancel context.CancelFunc
+	if c.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	out := &crawlOutcome{linked: make(map[string]bool), scope: sc}
+	var seeds []string
+	var seedsDropped bool
+	if c.sitemaps {
+		out.sitemap, seeds, seedsDropped = c.loadSitemaps(ctx, base, sc)
+	}
+
+	var state *crawlState
+	restored := &restoredState{}
+	if c.stateDir != "" {
+		state, restored, err = openState(c.stateDir, startURL, c.resume)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err := state.close(); err != nil {
+				log.Print(i18n.T("log.crawl.state-failed", err))
+			}
+		}()
+	}
+
+	front := newFrontier()
+	// sections — число страниц каждого раздела, потраченных из лимита раздела
+	sections := make(map[string]int)
+	// outOfScope — ссылки за границами сканирования, о пропуске которых уже отправлено событие
+	outOfScope := make(map[string]bool)
+	// Просканированные до перезапуска страницы не загружаются повторно и учитываются в лимитах
+	for _, res := range restored.results {
+		front.visited(res.URL)
+		sections[sc.section(res.URL)]++
+		// Каталог правил в состоянии не сохраняется: восстановленные отчёты получают каталог реестра
+		if res.Report != nil && c.rules != nil {
+			res.Report.Rules = c.rules.Catalog()
+		}
+		if res.Report != nil && res.Report.StatusCode == 200 {
+			for _, link := range internalLinks(res.Report, sc) {
+				out.linked[link] = true
+			}
+		}
+	}
+	out.results = restored.results
+	out.truncated = restored.truncated
+	if c.resume {
+		log.Print(i18n.T("log.crawl.resumed", len(restored.results), len(restored.tasks)))
 	}
-	allowedHost := base.Hostname()
 
+	// mu защищает visited, sections, outOfScope и поля out, которые пишут рабочие горутины
 	var mu sync.Mutex
-	seen := make(map[string]bool)
-	var results []report.CrawlResult
+	visited := len(restored.results)
+	// truncate — отмечает, что часть ссылок отброшена лимитами; вызывается под mu
+	truncate := func() {
+		if !out.truncated {
+			out.truncated = true
+			state.markTruncated()
+		}
+	}
+	// Записи карт, не поместившиеся в лимит, не сканируются: покрытие картой неполное
+	if seedsDropped {
+		truncate()
+	}
 
-	taskQueue := make(chan crawlTask, c.maxPages)
+	// Записи карт сайта — точки входа наравне со стартовой страницей;
+	// после них — задачи, не завершённые до перезапуска
+	initial := []crawlTask{{URL: startURL}}
+	for _, seed := range seeds {
+		initial = append(initial, crawlTask{URL: seed})
+	}
+	initial = append(initial, restored.tasks...)
+	state.addTasks(front.seed(initial...)...)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-	defer cancel()
+	// Отмена ctx останавливает выдачу задач, ожидающие рабочие горутины завершаются
+	stopFrontier := context.AfterFunc(ctx, front.close)
+	defer stopFrontier()
 
 	g, gCtx := errgroup.WithContext(ctx)
-
 	for i := 0; i < c.concurrency; i++ {
 		g.Go(func() error {
 			for {
-				select {
-				case <-gCtx.Done():
+				task, ok := front.next()
+				if !ok {
 					return nil
-				case task, ok := <-taskQueue:
-					if !ok {
-						return nil
-					}
-
-					if task.Depth > c.maxDepth {
-						continue
-					}
-
-					normalizedURL := normalizeURL(task.URL)
+				}
 
+				// Ссылки сверх лимитов тоже проходят через очередь, чтобы каждая найденная
+				// ссылка была либо просканирована, либо отмечена событием пропуска
+				if task.Depth > c.maxDepth {
 					mu.Lock()
-					if seen[normalizedURL] || len(seen) >= c.maxPages {
-						mu.Unlock()
-						continue
-					}
-					seen[normalizedURL] = true
-					currentCount := len(seen)
+					truncate()
 					mu.Unlock()
-
-					log.Printf("➤ Анализ %s (%d/%d)", task.URL, currentCount, c.maxPages)
-
-					var res report.CrawlResult
-					if !c.robots.Allowed(c.userAgent, task.URL) {
-						res = report.CrawlResult{
-							URL:   task.URL,
-							Error: fmt.Errorf("запрещено robots.txt"),
-						}
-					} else {
-						rep := analyzer.AnalyzeURL(task.URL)
-						res = report.CrawlResult{URL: task.URL, Report: rep}
+					c.emit(events.PageSkipped(task.URL, task.Depth, events.ReasonMaxDepth, i18n.T("msg.skip.max-depth")))
+					front.done(task, nil)
+					continue
+				}
+				mu.Lock()
+				if visited >= c.maxPages {
+					truncate()
+					mu.Unlock()
+					c.emit(events.PageSkipped(task.URL, task.Depth, events.ReasonMaxPages, i18n.T("msg.skip.max-pages")))
+					front.done(task, nil)
+					continue
+				}
+				section := sc.section(task.URL)
+				if c.sectionMax > 0 && sections[section] >= c.sectionMax {
+					truncate()
+					mu.Unlock()
+					c.emit(events.PageSkipped(task.URL, task.Depth, events.ReasonSectionPages, i18n.T("msg.skip.section-pages", section, c.sectionMax)))
+					front.done(task, nil)
+					continue
+				}
+				sections[section]++
+				visited++
+				currentCount := visited
+				mu.Unlock()
+
+				log.Print("➤ " + i18n.T("log.crawl.analyze", task.URL, currentCount, c.maxPages))
+
+				var res report.CrawlResult
+				if !c.robots.Allowed(gCtx, task.URL) {
+					res = report.CrawlResult{
+						URL:   task.URL,
+						Error: errors.New(i18n.T("msg.skip.robots")),
 					}
+				} else {
+					c.emit(events.PageStarted(task.URL, task.Depth))
+					rep := analyzer.AnalyzeURL(gCtx, task.URL, c.analyzeOpts...)
+					res = report.CrawlResult{URL: task.URL, Report: rep}
+				}
 
-					mu.Lock()
-					results = append(results, res)
-					mu.Unlock()
+				// Страница, загрузка которой прервана отменой, в отчёт не попадает;
+				// при продолжении сканирования она загружается заново. События итога
+				// отправляются только после этой проверки, чтобы поток совпадал с отчётом.
+				if gCtx.Err() != nil {
+					if res.Report != nil {
+						c.emit(events.PageSkipped(task.URL, task.Depth, events.ReasonCanceled, i18n.T("msg.skip.canceled")))
+					}
+					return nil
+				}
+				if res.Report != nil {
+					c.emit(events.PageFinished(task.URL, task.Depth, res.Report))
+				} else {
+					c.emit(events.PageSkipped(task.URL, task.Depth, events.ReasonRobots, res.Error.Error()))
+				}
 
-					if task.Depth < c.maxDepth && res.Report != nil && res.Report.StatusCode == 200 {
-						newURLs := c.extractInternalLinks(res.Report, allowedHost)
-						mu.Lock()
-						for _, nextURL := range newURLs {
-							normalizedNext := normalizeURL(nextURL)
-							if !seen[normalizedNext] && len(seen) < c.maxPages {
-								select {
-								case taskQueue <- crawlTask{URL: nextURL, Depth: task.Depth + 1}:
-								case <-gCtx.Done():
-									mu.Unlock()
-									return nil
-								default:
-								}
-							}
+				var links, next, outside []string
+				if res.Report != nil && res.Report.StatusCode == 200 {
+					links = internalLinks(res.Report, sc)
+					for _, link := range links {
+						if sc.follows(link) {
+							next = append(next, link)
+						} else {
+							outside = append(outside, link)
 						}
-						mu.Unlock()
 					}
+				}
 
-					time.Sleep(200 * time.Millisecond)
+				mu.Lock()
+				out.results = append(out.results, res)
+				for _, link := range links {
+					out.linked[link] = true
+				}
+				// Ссылка за границами сканирования отмечается событием пропуска один раз
+				var skipped []string
+				for _, link := range outside {
+					if !outOfScope[link] {
+						outOfScope[link] = true
+						skipped = append(skipped, link)
+					}
 				}
+				mu.Unlock()
+				for _, link := range skipped {
+					c.emit(events.PageSkipped(link, task.Depth+1, events.ReasonScope, i18n.T("msg.skip.scope")))
+				}
+
+				// Очередь пишется раньше результата: если процесс оборвётся между записями,
+				// страница будет просканирована заново, но её ссылки не потеряются
+				state.addTasks(front.done(task, next)...)
+				state.addResult(res)
+
+				time.Sleep(200 * time.Millisecond)
 			}
 		})
 	}
-
-	select {
-	case taskQueue <- crawlTask{URL: startURL, Depth: 0}:
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	}
-
 	_ = g.Wait()
 
-	close(taskQueue)
+	out.stopped = stopReason(ctx.Err())
+	c.emit(events.CrawlFinished(out.results, started, out.stopped))
 
-	log.Printf("Сканирование завершено. Обработано %d страниц", len(results))
-	return results, nil
+	log.Print(i18n.T("log.crawl.done", len(out.results)))
+	return out, nil
 }
 
-// CrawlSite — формирует сводный отчёт
-func (c *Crawler) CrawlSite(startURL string) (*report.SiteReport, error) {
-	results, err := c.Crawl(startURL)
-	if err != nil {
+// stopReason — причина досрочной остановки сканирования по ошибке контекста
+func stopReason(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, context.DeadlineExceeded):
+		return report.StopTimeout
+	default:
+		return report.StopCanceled
+	}
+}
+
+// CrawlSite — формирует сводный отчёт. При отмене ctx или истечении лимита времени
+// отчёт содержит уже проанализированные страницы, а причина остановки записывается в Stopped.
+func (c *Crawler) CrawlSite(ctx context.Context, startURL string) (*report.SiteReport, error) {
+	out, err := c.crawl(ctx, startURL)
+	if out == nil || (err != nil && out.stopped == "") {
 		return nil, err
 	}
 
 	var mainRep *report.SEOReport
-	for _, res := range results {
+	for _, res := range out.results {
 		if res.Report != nil && res.URL == startURL {
 			mainRep = res.Report
 			break
 		}
 	}
 
-	if mainRep == nil {
-		mainRep = analyzer.AnalyzeURL(startURL)
+	if mainRep == nil && ctx.Err() == nil {
+		mainRep = analyzer.AnalyzeURL(ctx, startURL, c.analyzeOpts...)
 	}
 
-	return &report.SiteReport{
+	site := &report.SiteReport{
 		MainURL:    startURL,
 		MainReport: mainRep,
-		SubReports: results,
-	}, nil
+		SubReports: out.results,
+		Stopped:    out.stopped,
+	}
+	if c.rules != nil {
+		site.Rules = c.rules.Catalog()
+	}
+	if out.sitemap != nil {
+		site.Sitemap = c.sitemapCoverage(ctx, startURL, out)
+	}
+	return site, nil
 }
 
 func normalizeURL(raw string) string {
@@ -196,13 +454,14 @@ func normalizeURL(raw string) string {
 	return strings.TrimRight(u.String(), "/")
 }
 
-func (c *Crawler) extractInternalLinks(rep *report.SEOReport, host string) []string {
+// internalLinks — нормализованные ссылки страницы на хосты сканирования, без повторов
+func internalLinks(rep *report.SEOReport, sc *scope) []string {
 	var internal []string
 	seen := make(map[string]bool)
 
 	for _, link := range rep.AllLinks {
 		u, err := url.Parse(link)
-		if err != nil || u.Hostname() != host {
+		if err != nil || !sc.allowsHost(u.Hostname()) {
 			continue
 		}
 		normalized := normalizeURL(u.String())
@@ -0,0 +1,94 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"bullwler/internal/events"
+)
+
+func TestCrawlReportsLinksOutsideScope(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" || r.URL.Path == "/sitemap.xml" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><title>page</title></head><body>
+<a href="/blog/x/1">1</a> <a href="/blog/x/2">2</a> <a href="/shop/item">shop</a>
+</body></html>`)
+	}))
+	defer srv.Close()
+
+	var mu sync.Mutex
+	skipped := make(map[string][]string)
+	c := NewCrawler(
+		WithSitemaps(false),
+		WithPathPrefixes("/blog/"),
+		WithSectionBudget(1),
+		WithEvents(func(e events.Event) {
+			if e.Type == events.PageSkippedType {
+				mu.Lock()
+				skipped[e.Reason] = append(skipped[e.Reason], e.URL)
+				mu.Unlock()
+			}
+		}),
+	)
+	if _, err := c.Crawl(context.Background(), srv.URL+"/"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := skipped[events.ReasonScope]; len(got) != 1 || got[0] != srv.URL+"/shop/item" {
+		t.Errorf("scope skips = %v, want only /shop/item once", got)
+	}
+	if got := skipped[events.ReasonSectionPages]; len(got) != 1 || got[0] != srv.URL+"/blog/x/2" {
+		t.Errorf("section skips = %v, want /blog/x/2", got)
+	}
+}
+
+func TestCanceledPageIsNotReportedFinished(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/slow" {
+			http.NotFound(w, r)
+			return
+		}
+		// Отмена сканирования во время загрузки страницы
+		cancel()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	var mu sync.Mutex
+	var got []events.Event
+	c := NewCrawler(WithSitemaps(false), WithEvents(func(e events.Event) {
+		mu.Lock()
+		got = append(got, e)
+		mu.Unlock()
+	}))
+	results, err := c.Crawl(ctx, srv.URL+"/slow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 0 {
+		t.Errorf("results = %d, want the canceled page left out", len(results))
+	}
+
+	canceled := false
+	for _, e := range got {
+		if e.Type == events.PageFinishedType {
+			t.Errorf("page_finished for %s, which is not in the report", e.URL)
+		}
+		if e.Type == events.PageSkippedType && e.Reason == events.ReasonCanceled {
+			canceled = true
+		}
+	}
+	if !canceled {
+		t.Error("want a page_skipped event with reason canceled")
+	}
+}
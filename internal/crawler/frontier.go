@@ -0,0 +1,191 @@
+package crawler
+
+import (
+	"sort"
+	"sync"
+)
+
+// frontier — очередь сканирования в ширину. Задачи выдаются уровнями: следующий уровень
+// открывается, только когда текущий выдан и обработан целиком, поэтому страницы
+// анализируются строго по возрастанию глубины. Внутри уровня порядок не зависит от того,
+// какая страница загрузилась первой: задачи упорядочены по позиции родителя в предыдущем
+// уровне и по порядку ссылки на его странице. Очередь не ограничена по размеру, и каждая
+// добавленная ссылка выдаётся ровно один раз.
+type frontier struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	// current — задачи текущего уровня в порядке выдачи; issued — сколько из них выдано
+	current []crawlTask
+	issued  int
+	// levels — следующие уровни: глубина → нормализованный URL → задача
+	levels map[int]map[string]*queuedTask
+	// known — глубина, на которой URL поставлен в очередь или просканирован
+	known map[string]int
+	// active — выданные и ещё не завершённые задачи
+	active int
+	// seq — порядковый номер для задач без родителя (стартовая страница, карты сайта, восстановленные)
+	seq    int
+	closed bool
+}
+
+// queuedTask — задача будущего уровня с ключом порядка
+type queuedTask struct {
+	task crawlTask
+	// parent — позиция родителя в его уровне, -1 для задач без родителя; link — номер ссылки
+	parent, link int
+}
+
+func newFrontier() *frontier {
+	f := &frontier{
+		levels: make(map[int]map[string]*queuedTask),
+		known:  make(map[string]int),
+	}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+// visited — отмечает уже просканированные URL, чтобы они не попали в очередь снова
+func (f *frontier) visited(urls ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, u := range urls {
+		f.known[normalizeURL(u)] = -1
+	}
+}
+
+// seed — добавляет задачи без родителя; возвращает те, что действительно поставлены в очередь
+func (f *frontier) seed(tasks ...crawlTask) []crawlTask {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var added []crawlTask
+	for _, t := range tasks {
+		if f.add(t, -1, f.seq) {
+			added = append(added, t)
+		}
+		f.seq++
+	}
+	f.cond.Broadcast()
+	return added
+}
+
+// next — выдаёт следующую задачу, дожидаясь завершения текущего уровня, если он выдан целиком.
+// Возвращает false, когда очередь исчерпана и задач в обработке нет, или после close.
+func (f *frontier) next() (crawlTask, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for {
+		if f.closed {
+			return crawlTask{}, false
+		}
+		if f.issued < len(f.current) {
+			t := f.current[f.issued]
+			f.issued++
+			f.active++
+			return t, true
+		}
+		if f.active == 0 {
+			if !f.advance() {
+				f.closed = true
+				f.cond.Broadcast()
+				return crawlTask{}, false
+			}
+			continue
+		}
+		f.cond.Wait()
+	}
+}
+
+// done — завершает задачу и ставит в очередь найденные на странице ссылки на следующий уровень;
+// возвращает ссылки, которые действительно добавлены
+func (f *frontier) done(task crawlTask, links []string) []crawlTask {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var added []crawlTask
+	for i, link := range links {
+		t := crawlTask{URL: link, Depth: task.Depth + 1}
+		if f.add(t, task.pos, i) {
+			added = append(added, t)
+		}
+	}
+	f.active--
+	f.cond.Broadcast()
+	return added
+}
+
+// close — останавливает выдачу задач и будит ожидающих
+func (f *frontier) close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	f.cond.Broadcast()
+}
+
+// add — ставит задачу на её уровень. URL, уже просканированный или стоящий в очереди
+// на той же или меньшей глубине, не добавляется; на той же глубине сохраняется
+// наименьший ключ порядка. Вызывается под mu.
+func (f *frontier) add(t crawlTask, parent, link int) bool {
+	n := normalizeURL(t.URL)
+	depth, ok := f.known[n]
+	if ok && (depth < 0 || depth < t.Depth) {
+		return false
+	}
+	if ok {
+		q := f.levels[depth][n]
+		// URL уже в текущем уровне
+		if q == nil {
+			return false
+		}
+		if depth == t.Depth {
+			if parent < q.parent || (parent == q.parent && link < q.link) {
+				q.task, q.parent, q.link = t, parent, link
+			}
+			return false
+		}
+		// Ссылка найдена ближе к началу, чем при постановке в очередь: переносится на меньшую глубину
+		delete(f.levels[depth], n)
+	}
+	f.known[n] = t.Depth
+	level := f.levels[t.Depth]
+	if level == nil {
+		level = make(map[string]*queuedTask)
+		f.levels[t.Depth] = level
+	}
+	level[n] = &queuedTask{task: t, parent: parent, link: link}
+	return true
+}
+
+// advance — открывает ближайший непустой уровень; false, если уровней не осталось.
+// Вызывается под mu.
+func (f *frontier) advance() bool {
+	if len(f.levels) == 0 {
+		return false
+	}
+	depth := -1
+	for d := range f.levels {
+		if depth < 0 || d < depth {
+			depth = d
+		}
+	}
+	level := f.levels[depth]
+	delete(f.levels, depth)
+
+	queued := make([]*queuedTask, 0, len(level))
+	for _, q := range level {
+		queued = append(queued, q)
+	}
+	sort.Slice(queued, func(i, j int) bool {
+		if queued[i].parent != queued[j].parent {
+			return queued[i].parent < queued[j].parent
+		}
+		return queued[i].link < queued[j].link
+	})
+
+	f.current = make([]crawlTask, len(queued))
+	f.issued = 0
+	for i, q := range queued {
+		q.task.pos = i
+		f.current[i] = q.task
+	}
+	return true
+}
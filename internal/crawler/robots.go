@@ -1,55 +1,101 @@
 package crawler
 
 import (
-	"net/http"
+	"context"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/temoto/robotstxt"
+
+	"bullwler/internal/fetch"
 )
 
+// robotsTimeout - таймаут загрузки robots.txt
+const robotsTimeout = 10 * time.Second
+
 // RobotsClient - управляет загрузкой и кэшированием robots.txt
 type RobotsClient struct {
-	client *http.Client
-	cache  map[string]*robotstxt.RobotsData
+	fetcher *fetch.Fetcher
+	mu      sync.Mutex
+	cache   map[string]*robotstxt.RobotsData
 }
 
 // NewRobotsClient - создает новый инстанс клиента для работы с robots.txt
 func NewRobotsClient() *RobotsClient {
 	return &RobotsClient{
-		client: &http.Client{Timeout: 10 * time.Second},
-		cache:  make(map[string]*robotstxt.RobotsData),
+		fetcher: fetch.Default(),
+		cache:   make(map[string]*robotstxt.RobotsData),
 	}
 }
 
-// Allowed - метод определения доступности
-func (rc *RobotsClient) Allowed(userAgent, targetURL string) bool {
+// Allowed - метод определения доступности для токена краулера из идентичности фетчера.
+// Если robots.txt не удалось загрузить, сканирование разрешено; при отмене ctx результат не кэшируется.
+func (rc *RobotsClient) Allowed(ctx context.Context, targetURL string) bool {
 	parsed, err := url.Parse(targetURL)
 	if err != nil {
 		return false
 	}
 
-	host := parsed.Scheme + "://" + parsed.Host
-	if _, ok := rc.cache[host]; !ok {
-		robotsURL := host + "/robots.txt"
-		resp, err := rc.client.Get(robotsURL)
-		if err != nil {
-			rc.cache[host] = nil
-			return true
-		}
-		defer resp.Body.Close()
+	robots := rc.get(ctx, parsed)
+	if robots == nil {
+		return true
+	}
 
-		robots, err := robotstxt.FromResponse(resp)
-		if err != nil {
-			rc.cache[host] = nil
-			return true
-		}
-		rc.cache[host] = robots
+	// Правила robots.txt сопоставляются с путём и строкой запроса, а не с полным URL
+	path := parsed.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if parsed.RawQuery != "" {
+		path += "?" + parsed.RawQuery
 	}
+	return robots.TestAgent(path, rc.fetcher.Agent().Token)
+}
 
-	if rc.cache[host] == nil {
-		return true
+// Sitemaps - адреса карт сайта из директив Sitemap в robots.txt сайта siteURL
+func (rc *RobotsClient) Sitemaps(ctx context.Context, siteURL string) []string {
+	parsed, err := url.Parse(siteURL)
+	if err != nil {
+		return nil
+	}
+	if robots := rc.get(ctx, parsed); robots != nil {
+		return robots.Sitemaps
+	}
+	return nil
+}
+
+// get - возвращает разобранный robots.txt хоста из кэша, загружая его при первом обращении
+func (rc *RobotsClient) get(ctx context.Context, target *url.URL) *robotstxt.RobotsData {
+	host := target.Scheme + "://" + target.Host
+	rc.mu.Lock()
+	robots, ok := rc.cache[host]
+	rc.mu.Unlock()
+	if ok {
+		return robots
+	}
+
+	robots = rc.fetch(ctx, host+"/robots.txt")
+	if ctx.Err() != nil {
+		return nil
+	}
+	rc.mu.Lock()
+	rc.cache[host] = robots
+	rc.mu.Unlock()
+	return robots
+}
+
+func (rc *RobotsClient) fetch(ctx context.Context, robotsURL string) *robotstxt.RobotsData {
+	ctx, cancel := context.WithTimeout(ctx, robotsTimeout)
+	defer cancel()
+	resp, err := rc.fetcher.Get(ctx, robotsURL)
+	if err != nil {
+		return nil
 	}
 
-	return rc.cache[host].TestAgent(targetURL, userAgent)
+	robots, err := robotstxt.FromStatusAndBytes(resp.StatusCode, resp.Data)
+	if err != nil {
+		return nil
+	}
+	return robots
 }
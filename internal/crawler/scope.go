@@ -0,0 +1,197 @@
+package crawler
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"bullwler/internal/i18n"
+)
+
+// scope — границы сканирования: хосты, ссылки на которые считаются внутренними,
+// префиксы путей разделов и шаблоны URL
+type scope struct {
+	host string
+	// domain — хост без www., поддомены которого разрешены при subdomains
+	domain     string
+	subdomains bool
+	// hosts — дополнительные хосты; запись "*.example.com" разрешает домен и его поддомены
+	hosts    []string
+	prefixes []string
+	include  []*regexp.Regexp
+	exclude  []*regexp.Regexp
+}
+
+func (c *Crawler) newScope(base *url.URL) *scope {
+	host := strings.ToLower(base.Hostname())
+	s := &scope{
+		host:       host,
+		domain:     strings.TrimPrefix(host, "www."),
+		subdomains: c.subdomains,
+		include:    c.include,
+		exclude:    c.exclude,
+	}
+	for _, h := range c.hosts {
+		s.hosts = append(s.hosts, strings.ToLower(strings.TrimSpace(h)))
+	}
+	for _, p := range c.prefixes {
+		s.prefixes = append(s.prefixes, cleanPrefix(p))
+	}
+	return s
+}
+
+// ScopeHosts — хосты сканирования, начатого со startURL, в виде шаблонов fetch.WithCredentialHosts:
+// стартовый хост, при subdomains — его домен без www. с поддоменами, и дополнительные хосты
+func ScopeHosts(startURL string, subdomains bool, extra []string) []string {
+	var hosts []string
+	if u, err := url.Parse(startURL); err == nil && u.Hostname() != "" {
+		host := strings.ToLower(u.Hostname())
+		hosts = append(hosts, host)
+		if subdomains {
+			hosts = append(hosts, "*."+strings.TrimPrefix(host, "www."))
+		}
+	}
+	return append(hosts, extra...)
+}
+
+// cleanPrefix — приводит префикс к виду "/blog" без завершающей косой черты,
+// как у нормализованных URL
+func cleanPrefix(p string) string {
+	p = strings.TrimSpace(p)
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	return strings.TrimRight(p, "/")
+}
+
+// allowsHost — ссылка на хост считается внутренней
+func (s *scope) allowsHost(host string) bool {
+	host = strings.ToLower(host)
+	if host == s.host {
+		return true
+	}
+	if s.subdomains && (host == s.domain || strings.HasSuffix(host, "."+s.domain)) {
+		return true
+	}
+	for _, h := range s.hosts {
+		if wildcard, ok := strings.CutPrefix(h, "*."); ok {
+			if host == wildcard || strings.HasSuffix(host, "."+wildcard) {
+				return true
+			}
+		} else if host == h {
+			return true
+		}
+	}
+	return false
+}
+
+// follows — внутренняя ссылка попадает в сканирование: путь лежит в одном из разделов
+// и URL проходит шаблоны include/exclude
+func (s *scope) follows(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || !s.allowsHost(u.Hostname()) {
+		return false
+	}
+	if len(s.prefixes) > 0 && s.prefix(u.Path) < 0 {
+		return false
+	}
+	for _, re := range s.exclude {
+		if re.MatchString(rawURL) {
+			return false
+		}
+	}
+	if len(s.include) == 0 {
+		return true
+	}
+	for _, re := range s.include {
+		if re.MatchString(rawURL) {
+			return true
+		}
+	}
+	return false
+}
+
+// prefix — индекс самого длинного префикса, в котором лежит путь, или -1.
+// Префикс совпадает по целым сегментам: "/blog" включает "/blog" и "/blog/post", но не "/blogger".
+func (s *scope) prefix(path string) int {
+	path = strings.TrimRight(path, "/")
+	best := -1
+	for i, p := range s.prefixes {
+		if (path == p || strings.HasPrefix(path, p+"/")) && (best < 0 || len(p) > len(s.prefixes[best])) {
+			best = i
+		}
+	}
+	return best
+}
+
+// section — раздел страницы для лимита страниц раздела: хост и первый сегмент пути
+// после префикса сканирования ("example.com/blog/2024" для /blog/2024/post при префиксе /blog)
+func (s *scope) section(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	path := strings.TrimRight(u.Path, "/")
+	base := ""
+	if i := s.prefix(path); i >= 0 {
+		base = s.prefixes[i]
+		path = strings.TrimPrefix(path, base)
+	}
+	segment, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if segment == "" {
+		return strings.ToLower(u.Host) + base
+	}
+	return strings.ToLower(u.Host) + base + "/" + segment
+}
+
+// CompileGlob — переводит glob-шаблон URL в регулярное выражение. Шаблон, начинающийся с "/",
+// сопоставляется с путём на любом хосте, иначе — с URL целиком. "*" — любые символы, кроме "/",
+// "**" — любые символы, "?" — один символ, кроме "/", "{a,b}" — одна из альтернатив.
+// Завершающий "/**" совпадает и с самим разделом: "/blog/**" включает "/blog".
+func CompileGlob(glob string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	if strings.HasPrefix(glob, "/") {
+		b.WriteString(`[a-zA-Z][a-zA-Z0-9+.-]*://[^/]+`)
+	}
+	rest := glob
+	if trimmed, ok := strings.CutSuffix(glob, "/**"); ok {
+		rest = trimmed
+	}
+	depth := 0
+	for i := 0; i < len(rest); i++ {
+		switch ch := rest[i]; {
+		case ch == '*' && i+1 < len(rest) && rest[i+1] == '*':
+			b.WriteString(".*")
+			i++
+		case ch == '*':
+			b.WriteString("[^/]*")
+		case ch == '?':
+			b.WriteString("[^/]")
+		case ch == '{':
+			b.WriteString("(?:")
+			depth++
+		case ch == '}' && depth > 0:
+			b.WriteString(")")
+			depth--
+		case ch == ',' && depth > 0:
+			b.WriteString("|")
+		default:
+			b.WriteString(regexp.QuoteMeta(rest[i : i+1]))
+		}
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.glob.invalid", glob), errors.New(i18n.T("err.glob.brace")))
+	}
+	if len(rest) < len(glob) {
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.glob.invalid", glob), err)
+	}
+	return re, nil
+}
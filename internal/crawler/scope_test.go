@@ -0,0 +1,133 @@
+package crawler
+
+import (
+	"net/url"
+	"regexp"
+	"testing"
+)
+
+func TestCompileGlob(t *testing.T) {
+	tests := []struct {
+		glob  string
+		url   string
+		match bool
+	}{
+		// "**" пересекает сегменты, "*" — нет
+		{"/blog/**", "https://example.com/blog/2024/05/post", true},
+		{"**/tag/**", "https://example.com/blog/tag/go", true},
+		{"/blog/*", "https://example.com/blog/post", true},
+		{"/blog/*", "https://example.com/blog/2024/post", false},
+		{"/blog/*/post", "https://example.com/blog/2024/post", true},
+		// Завершающий "/**" включает сам раздел, но не соседние пути
+		{"/blog/**", "https://example.com/blog", true},
+		{"/blog/**", "https://example.com/blogger", false},
+		{"/blog/**", "https://example.com/news/blog/post", false},
+		// "?" — ровно один символ, кроме "/"
+		{"/page-?", "https://example.com/page-1", true},
+		{"/page-?", "https://example.com/page-10", false},
+		{"/a?b", "https://example.com/a/b", false},
+		// "{a,b}" — альтернативы
+		{"/{blog,docs}/**", "https://example.com/docs/api", true},
+		{"/{blog,docs}/**", "https://example.com/shop/item", false},
+		{"/file.{html,htm}", "https://example.com/file.htm", true},
+		// Метасимволы регулярных выражений совпадают буквально
+		{"/file.html", "https://example.com/fileXhtml", false},
+		{"/a+b/(x)", "https://example.com/a+b/(x)", true},
+		{"/a+b/(x)", "https://example.com/aab/x", false},
+		{"/price$/[1]", "https://example.com/price$/[1]", true},
+		// Шаблон без ведущего "/" сопоставляется с URL целиком
+		{"https://docs.example.com/**", "https://docs.example.com/api", true},
+		{"https://docs.example.com/**", "https://example.com/api", false},
+		{"/blog/**", "https://other.example.org/blog/post", true},
+	}
+	for _, tt := range tests {
+		re, err := CompileGlob(tt.glob)
+		if err != nil {
+			t.Fatalf("CompileGlob(%q): %v", tt.glob, err)
+		}
+		if got := re.MatchString(tt.url); got != tt.match {
+			t.Errorf("CompileGlob(%q).MatchString(%q) = %v, want %v", tt.glob, tt.url, got, tt.match)
+		}
+	}
+
+	if _, err := CompileGlob("/{blog,docs"); err == nil {
+		t.Error("unclosed brace: want error, got nil")
+	}
+}
+
+func TestScopeAllowsHost(t *testing.T) {
+	tests := []struct {
+		name       string
+		start      string
+		subdomains bool
+		hosts      []string
+		host       string
+		allowed    bool
+	}{
+		{"start host", "https://www.example.com/", false, nil, "www.example.com", true},
+		{"start host case", "https://www.example.com/", false, nil, "WWW.Example.com", true},
+		{"subdomain without flag", "https://www.example.com/", false, nil, "blog.example.com", false},
+		{"bare domain with subdomains", "https://www.example.com/", true, nil, "example.com", true},
+		{"subdomain with subdomains", "https://www.example.com/", true, nil, "blog.example.com", true},
+		{"lookalike domain", "https://www.example.com/", true, nil, "badexample.com", false},
+		{"extra host", "https://example.com/", false, []string{"docs.example.org"}, "docs.example.org", true},
+		{"extra host subdomain", "https://example.com/", false, []string{"docs.example.org"}, "api.docs.example.org", false},
+		{"wildcard host domain", "https://example.com/", false, []string{"*.cdn.net"}, "cdn.net", true},
+		{"wildcard host subdomain", "https://example.com/", false, []string{"*.cdn.net"}, "img.cdn.net", true},
+		{"wildcard host lookalike", "https://example.com/", false, []string{"*.cdn.net"}, "evilcdn.net", false},
+		{"other host", "https://example.com/", false, []string{" Docs.Example.org "}, "example.org", false},
+		{"trimmed extra host", "https://example.com/", false, []string{" Docs.Example.org "}, "docs.example.org", true},
+	}
+	for _, tt := range tests {
+		base, err := url.Parse(tt.start)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := NewCrawler(WithSubdomains(tt.subdomains), WithAllowedHosts(tt.hosts...))
+		if got := c.newScope(base).allowsHost(tt.host); got != tt.allowed {
+			t.Errorf("%s: allowsHost(%q) = %v, want %v", tt.name, tt.host, got, tt.allowed)
+		}
+	}
+}
+
+func TestScopeSection(t *testing.T) {
+	tests := []struct {
+		prefixes []string
+		url      string
+		section  string
+	}{
+		{nil, "https://example.com/", "example.com"},
+		{nil, "https://example.com/blog/a", "example.com/blog"},
+		{nil, "https://Example.com/blog/2024/post", "example.com/blog"},
+		{[]string{"/docs/"}, "https://example.com/docs/api/auth", "example.com/docs/api"},
+		{[]string{"/docs/"}, "https://example.com/docs", "example.com/docs"},
+		{[]string{"/docs", "/docs/api"}, "https://example.com/docs/api/v1/auth", "example.com/docs/api/v1"},
+		{[]string{"/docs/"}, "https://example.com/documents/x", "example.com/documents"},
+	}
+	base, _ := url.Parse("https://example.com/")
+	for _, tt := range tests {
+		sc := NewCrawler(WithPathPrefixes(tt.prefixes...)).newScope(base)
+		if got := sc.section(tt.url); got != tt.section {
+			t.Errorf("prefixes %v: section(%q) = %q, want %q", tt.prefixes, tt.url, got, tt.section)
+		}
+	}
+}
+
+func TestScopeFollows(t *testing.T) {
+	include, _ := CompileGlob("/blog/**")
+	exclude, _ := CompileGlob("**/tag/**")
+	base, _ := url.Parse("https://example.com/")
+	sc := NewCrawler(WithURLFilter([]*regexp.Regexp{include}, []*regexp.Regexp{exclude}), WithPathPrefixes("/blog/")).newScope(base)
+
+	for rawURL, want := range map[string]bool{
+		"https://example.com/blog/post":    true,
+		"https://example.com/blog":         true,
+		"https://example.com/blog/tag/go":  false,
+		"https://example.com/blogger/post": false,
+		"https://other.com/blog/post":      false,
+	} {
+		if got := sc.follows(rawURL); got != want {
+			t.Errorf("follows(%q) = %v, want %v", rawURL, got, want)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package crawler
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"bullwler/internal/i18n"
+	"bullwler/internal/report"
+	"bullwler/internal/sitemap"
+)
+
+// loadSitemaps — загружает карты сайта из директив Sitemap в robots.txt, а если их нет —
+// /sitemap.xml. Возвращает загруженные карты, URL в границах сканирования для очереди
+// и признак того, что часть записей не поместилась в лимит страниц.
+func (c *Crawler) loadSitemaps(ctx context.Context, base *url.URL, sc *scope) (*sitemap.Set, []string, bool) {
+	locations := c.robots.Sitemaps(ctx, base.String())
+	if len(locations) == 0 {
+		locations = []string{base.Scheme + "://" + base.Host + "/sitemap.xml"}
+	}
+	set := sitemap.Load(ctx, c.fetcher, locations)
+	log.Print(i18n.T("log.crawl.sitemap", len(set.URLs), len(set.Files)))
+
+	// Записи карт сканируются раньше ссылок, поэтому им отдаётся не больше половины лимита
+	// страниц за вычетом стартовой: остальное остаётся страницам, найденным по ссылкам
+	limit := (c.maxPages - 1) / 2
+	start := normalizeURL(base.String())
+	queued := make(map[string]bool)
+	var seeds []string
+	dropped := 0
+	for _, entry := range set.URLs {
+		n := normalizeURL(entry.Loc)
+		if n == start || queued[n] || !sc.follows(n) {
+			continue
+		}
+		queued[n] = true
+		if len(seeds) >= limit {
+			dropped++
+			continue
+		}
+		seeds = append(seeds, entry.Loc)
+	}
+	if dropped > 0 {
+		log.Print(i18n.T("log.crawl.sitemap-truncated", len(seeds), dropped, c.maxPages))
+	}
+	return set, seeds, dropped > 0
+}
+
+// sitemapCoverage — сопоставляет карты сайта с результатами сканирования: находит страницы-сироты,
+// страницы вне карты и записи с редиректом, ошибкой, noindex или запретом в robots.txt.
+// Проблемы просканированных страниц добавляются в их отчёты замечаниями seo.sitemap.*.
+func (c *Crawler) sitemapCoverage(ctx context.Context, startURL string, out *crawlOutcome) *report.SitemapReport {
+	set := out.sitemap
+	sr := &report.SitemapReport{
+		URLs:     len(set.URLs),
+		Partial:  out.stopped != "" || out.truncated,
+		Orphans:  []string{},
+		Unlisted: []string{},
+		Issues:   []report.SitemapIssue{},
+	}
+	loaded := false
+	for _, f := range set.Files {
+		file := report.SitemapFile{URL: f.URL, Index: f.Index, URLs: f.URLs}
+		if f.Err != nil {
+			file.Error = f.Err.Error()
+		} else {
+			loaded = true
+		}
+		sr.Files = append(sr.Files, file)
+	}
+	// Без карты сайта сравнивать не с чем: её отсутствие отмечает правило seo.sitemap.missing
+	if !loaded {
+		return sr
+	}
+
+	base, err := url.Parse(startURL)
+	if err != nil {
+		return sr
+	}
+	start := normalizeURL(startURL)
+
+	crawled := make(map[string]report.CrawlResult)
+	for _, res := range out.results {
+		crawled[normalizeURL(res.URL)] = res
+	}
+
+	listed := make(map[string]bool)
+	for _, entry := range set.URLs {
+		u, err := url.Parse(entry.Loc)
+		if err != nil || u.Hostname() != base.Hostname() {
+			continue
+		}
+		n := normalizeURL(entry.Loc)
+		listed[n] = true
+		// Записи за границами сканирования не проверяются: ссылки на них намеренно не просматривались
+		if n != start && !out.scope.follows(n) {
+			continue
+		}
+		rep := crawled[n].Report
+		ev := &report.Evidence{Snippet: entry.Loc}
+		issues := len(sr.Issues)
+
+		if !c.robots.Allowed(ctx, entry.Loc) {
+			sr.Issues = append(sr.Issues, report.SitemapIssue{URL: entry.Loc, Problem: report.SitemapBlocked})
+		} else if rep != nil {
+			if len(rep.Redirects) > 0 {
+				target := rep.Redirects[len(rep.Redirects)-1]
+				sr.Issues = append(sr.Issues, report.SitemapIssue{URL: entry.Loc, Problem: report.SitemapRedirect, Detail: target})
+				c.addFinding(rep, "seo.sitemap.redirect", i18n.T("msg.seo.sitemap.redirect", target), ev)
+			}
+			if rep.StatusCode >= 400 {
+				sr.Issues = append(sr.Issues, report.SitemapIssue{URL: entry.Loc, Problem: report.SitemapBroken, Detail: strconv.Itoa(rep.StatusCode)})
+				c.addFinding(rep, "seo.sitemap.broken", i18n.T("msg.seo.sitemap.broken", rep.StatusCode), ev)
+			}
+			if rep.Noindex {
+				sr.Issues = append(sr.Issues, report.SitemapIssue{URL: entry.Loc, Problem: report.SitemapNoindex})
+				c.addFinding(rep, "seo.sitemap.noindex", i18n.T("msg.seo.sitemap.noindex"), ev)
+			}
+		}
+
+		// Сиротами считаются только записи без других проблем: битые и закрытые URL уже отмечены
+		if n != start && !out.linked[n] && len(sr.Issues) == issues {
+			sr.Orphans = append(sr.Orphans, entry.Loc)
+			// При неполном сканировании ссылка может быть на непросмотренной странице
+			if rep != nil && !sr.Partial {
+				c.addFinding(rep, "seo.sitemap.orphan", i18n.T("msg.seo.sitemap.orphan"), ev)
+			}
+		}
+	}
+
+	candidates := []string{start}
+	for n := range out.linked {
+		// Страницы поддоменов и дополнительных хостов в карту этого сайта не входят
+		u, err := url.Parse(n)
+		if n == start || err != nil || u.Hostname() != base.Hostname() || !out.scope.follows(n) {
+			continue
+		}
+		candidates = append(candidates, n)
+	}
+	sort.Strings(candidates[1:])
+	for _, n := range candidates {
+		if listed[n] {
+			continue
+		}
+		if res, ok := crawled[n]; ok {
+			// В карту попадают только индексируемые канонические страницы с кодом 200
+			if rep := res.Report; rep == nil || !indexable(rep, n) {
+				continue
+			}
+			c.addFinding(res.Report, "seo.sitemap.unlisted", i18n.T("msg.seo.sitemap.unlisted"), nil)
+		} else if !c.robots.Allowed(ctx, n) {
+			continue
+		}
+		sr.Unlisted = append(sr.Unlisted, n)
+	}
+	return sr
+}
+
+// addFinding — добавляет замечание правила покрытия, если правило не выключено в реестре
+func (c *Crawler) addFinding(rep *report.SEOReport, ruleID, message string, ev *report.Evidence) {
+	if c.rules != nil && !c.rules.Enabled(ruleID) {
+		return
+	}
+	rep.AddFinding(ruleID, message, ev)
+}
+
+// indexable — страница отвечает 200 без редиректа, не запрещена к индексации
+// и не указывает canonical на другой адрес
+func indexable(rep *report.SEOReport, normalized string) bool {
+	if rep.StatusCode != 200 || len(rep.Redirects) > 0 || rep.Noindex {
+		return false
+	}
+	return rep.Canonical == "" || normalizeURL(rep.Canonical) == normalized
+}
+
+// SitemapEntries — записи для новой карты сайта по результатам сканирования: индексируемые
+// канонические страницы с кодом 200. Стартовая страница идёт первой, остальные — по алфавиту;
+// lastmod берётся из заголовка Last-Modified.
+func SitemapEntries(site *report.SiteReport) []sitemap.URL {
+	start := normalizeURL(site.MainURL)
+	seen := make(map[string]bool)
+	var entries []sitemap.URL
+	for _, rep := range site.Reports() {
+		n := normalizeURL(rep.URL)
+		if seen[n] || !indexable(rep, n) {
+			continue
+		}
+		seen[n] = true
+		entry := sitemap.URL{Loc: rep.URL}
+		if t, err := http.ParseTime(rep.LastModified); err == nil {
+			entry.LastMod = t.UTC().Format(time.RFC3339)
+		}
+		entries = append(entries, entry)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		ni, nj := normalizeURL(entries[i].Loc), normalizeURL(entries[j].Loc)
+		if ni == start || nj == start {
+			return ni == start && nj != start
+		}
+		return ni < nj
+	})
+	return entries
+}
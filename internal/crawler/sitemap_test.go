@@ -0,0 +1,104 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bullwler/internal/rules"
+)
+
+func TestSitemapCoverageRespectsDisabledRules(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sitemap.xml":
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+<url><loc>%[1]s/</loc></url>
+<url><loc>%[1]s/orphan</loc></url>
+</urlset>`, srv.URL)
+		case "/", "/orphan":
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, `<html><head><title>page</title></head><body><h1>page</h1></body></html>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	orphanFindings := func(reg *rules.Registry) int {
+		t.Helper()
+		site, err := NewCrawler(WithRules(reg), WithMaxPages(10)).CrawlSite(context.Background(), srv.URL+"/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if site.Sitemap == nil || len(site.Sitemap.Orphans) != 1 {
+			t.Fatalf("want one orphan in sitemap coverage, got %+v", site.Sitemap)
+		}
+		n := 0
+		for _, rep := range site.Reports() {
+			for _, f := range rep.Findings {
+				if f.RuleID == "seo.sitemap.orphan" {
+					n++
+				}
+			}
+		}
+		return n
+	}
+
+	if n := orphanFindings(rules.Default()); n != 1 {
+		t.Fatalf("enabled rule: orphan findings = %d, want 1", n)
+	}
+	reg := rules.Default()
+	if err := reg.SetEnabled("seo.sitemap.orphan", false); err != nil {
+		t.Fatal(err)
+	}
+	if n := orphanFindings(reg); n != 0 {
+		t.Errorf("disabled rule: orphan findings = %d, want 0", n)
+	}
+}
+
+func TestSitemapSeedsLeaveBudgetForLinks(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sitemap.xml":
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+			for i := range 10 {
+				fmt.Fprintf(w, `<url><loc>%s/listed/%d</loc></url>`, srv.URL, i)
+			}
+			fmt.Fprint(w, `</urlset>`)
+		case "/":
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, `<html><head><title>home</title></head><body><a href="/linked">linked</a></body></html>`)
+		case "/robots.txt":
+			http.NotFound(w, r)
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, `<html><head><title>page</title></head><body><h1>page</h1></body></html>`)
+		}
+	}))
+	defer srv.Close()
+
+	out, err := NewCrawler(WithMaxPages(5), WithConcurrency(1)).crawl(context.Background(), srv.URL+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	linked := false
+	for _, res := range out.results {
+		if res.URL == srv.URL+"/linked" {
+			linked = true
+		}
+	}
+	if !linked {
+		t.Errorf("page found through a link was not crawled: %d results", len(out.results))
+	}
+	if !out.truncated {
+		t.Error("sitemap entries over the budget: want truncated crawl")
+	}
+}
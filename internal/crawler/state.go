@@ -0,0 +1,274 @@
+package crawler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"bullwler/internal/i18n"
+	"bullwler/internal/report"
+)
+
+// stateVersion — версия формата состояния сканирования
+const stateVersion = 1
+
+// Файлы директории состояния
+const (
+	// stateMetaFile — параметры сканирования; перезаписывается целиком через временный файл
+	stateMetaFile = "state.json"
+	// stateFrontierFile — задачи, добавленные в очередь, по строке JSON на задачу
+	stateFrontierFile = "frontier.ndjson"
+	// stateResultsFile — результаты просканированных страниц, по строке JSON на страницу
+	stateResultsFile = "results.ndjson"
+)
+
+// stateMeta — параметры сохранённого сканирования
+type stateMeta struct {
+	Version  int       `json:"version"`
+	StartURL string    `json:"start_url"`
+	Started  time.Time `json:"started"`
+	// Truncated — часть ссылок уже отброшена лимитами страниц или глубины
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// crawlState — состояние сканирования на диске. Очередь и результаты дописываются
+// построчно сразу по ходу работы, поэтому после сбоя или Ctrl-C теряется не больше
+// недописанной строки, а страницы, бывшие в обработке, просто сканируются заново.
+type crawlState struct {
+	dir      string
+	mu       sync.Mutex
+	meta     stateMeta
+	frontier *os.File
+	results  *os.File
+	// err — первая ошибка записи; после неё состояние больше не пишется
+	err error
+}
+
+// restoredState — прогресс, восстановленный из директории состояния
+type restoredState struct {
+	// results — результаты уже просканированных страниц
+	results []report.CrawlResult
+	// tasks — задачи, добавленные в очередь, но не завершённые, без повторов
+	tasks     []crawlTask
+	truncated bool
+}
+
+// openState — открывает директорию состояния. Без resume прежнее состояние удаляется
+// и сканирование начинается заново; с resume восстанавливаются очередь и результаты.
+func openState(dir, startURL string, resume bool) (*crawlState, *restoredState, error) {
+	s := &crawlState{dir: dir}
+	restored := &restoredState{}
+
+	flags := os.O_RDWR | os.O_CREATE | os.O_APPEND
+	if resume {
+		data, err := os.ReadFile(filepath.Join(dir, stateMetaFile))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, errors.New(i18n.T("err.state.missing", dir))
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", i18n.T("err.state.open", dir), err)
+		}
+		if err := json.Unmarshal(data, &s.meta); err != nil || s.meta.Version != stateVersion {
+			return nil, nil, errors.New(i18n.T("err.state.format", dir))
+		}
+		if s.meta.StartURL != startURL {
+			return nil, nil, errors.New(i18n.T("err.state.start-url", s.meta.StartURL))
+		}
+		restored.truncated = s.meta.Truncated
+	} else {
+		flags |= os.O_TRUNC
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", i18n.T("err.state.open", dir), err)
+		}
+		s.meta = stateMeta{Version: stateVersion, StartURL: startURL, Started: time.Now().UTC()}
+		if err := s.writeMeta(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var err error
+	if s.results, err = openLog(dir, stateResultsFile, flags); err != nil {
+		return nil, nil, err
+	}
+	if s.frontier, err = openLog(dir, stateFrontierFile, flags); err != nil {
+		s.results.Close()
+		return nil, nil, err
+	}
+	if !resume {
+		return s, restored, nil
+	}
+
+	done := make(map[string]bool)
+	err = readLog(s.results, func(line []byte) {
+		var res report.CrawlResult
+		if json.Unmarshal(line, &res) == nil && res.URL != "" {
+			done[normalizeURL(res.URL)] = true
+			restored.results = append(restored.results, res)
+		}
+	})
+	if err != nil {
+		s.close()
+		return nil, nil, fmt.Errorf("%s: %w", i18n.T("err.state.open", dir), err)
+	}
+
+	queued := make(map[string]int)
+	err = readLog(s.frontier, func(line []byte) {
+		var task crawlTask
+		if json.Unmarshal(line, &task) != nil || task.URL == "" {
+			return
+		}
+		n := normalizeURL(task.URL)
+		if done[n] {
+			return
+		}
+		// Ссылка могла попасть в очередь с разных глубин: учитывается наименьшая
+		if i, ok := queued[n]; ok {
+			restored.tasks[i].Depth = min(restored.tasks[i].Depth, task.Depth)
+			return
+		}
+		queued[n] = len(restored.tasks)
+		restored.tasks = append(restored.tasks, task)
+	})
+	if err != nil {
+		s.close()
+		return nil, nil, fmt.Errorf("%s: %w", i18n.T("err.state.open", dir), err)
+	}
+	return s, restored, nil
+}
+
+func openLog(dir, name string, flags int) (*os.File, error) {
+	f, err := os.OpenFile(filepath.Join(dir, name), flags, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.state.open", dir), err)
+	}
+	return f, nil
+}
+
+// readLog — читает файл построчно и обрезает недописанную последнюю строку,
+// чтобы следующая запись не склеилась с ней
+func readLog(f *os.File, fn func(line []byte)) error {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	r := bufio.NewReader(f)
+	var complete int64
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		complete += int64(len(line))
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			fn(line)
+		}
+	}
+	return f.Truncate(complete)
+}
+
+// addTasks — записывает задачи, добавленные в очередь. Методы записи допускают nil:
+// без директории состояния сканирование ничего не сохраняет.
+func (s *crawlState) addTasks(tasks ...crawlTask) {
+	if s == nil {
+		return
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, t := range tasks {
+		_ = enc.Encode(t)
+	}
+	s.append(s.frontier, buf.Bytes())
+}
+
+// addResult — записывает результат просканированной страницы
+func (s *crawlState) addResult(res report.CrawlResult) {
+	if s == nil {
+		return
+	}
+	line, err := json.Marshal(res)
+	if err != nil {
+		s.fail(err)
+		return
+	}
+	s.append(s.results, append(line, '\n'))
+}
+
+// markTruncated — запоминает, что часть ссылок отброшена лимитами
+func (s *crawlState) markTruncated() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.meta.Truncated || s.err != nil {
+		return
+	}
+	s.meta.Truncated = true
+	if err := s.writeMeta(); err != nil {
+		s.err = err
+	}
+}
+
+func (s *crawlState) append(f *os.File, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return
+	}
+	// Строка пишется одним вызовом, чтобы при сбое оборваться могла только последняя
+	if _, err := f.Write(data); err != nil {
+		s.err = fmt.Errorf("%s: %w", i18n.T("err.state.write", s.dir), err)
+	}
+}
+
+func (s *crawlState) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = fmt.Errorf("%s: %w", i18n.T("err.state.write", s.dir), err)
+	}
+}
+
+// writeMeta — атомарно перезаписывает state.json
+func (s *crawlState) writeMeta() error {
+	data, err := json.MarshalIndent(s.meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(s.dir, stateMetaFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("%s: %w", i18n.T("err.state.write", s.dir), err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("%s: %w", i18n.T("err.state.write", s.dir), err)
+	}
+	return nil
+}
+
+// close — закрывает файлы и возвращает первую ошибку записи
+func (s *crawlState) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, f := range []*os.File{s.frontier, s.results} {
+		if f == nil {
+			continue
+		}
+		if err := f.Close(); err != nil && s.err == nil {
+			s.err = fmt.Errorf("%s: %w", i18n.T("err.state.write", s.dir), err)
+		}
+	}
+	return s.err
+}
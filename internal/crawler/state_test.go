@@ -0,0 +1,170 @@
+package crawler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"bullwler/internal/report"
+	"bullwler/internal/rules"
+)
+
+const stateStart = "https://example.com/"
+
+// appendRaw — дописывает в файл состояния байты как есть, имитируя оборванную запись
+func appendRaw(t *testing.T, path, data string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func fileSize(t *testing.T, path string) int64 {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info.Size()
+}
+
+func TestOpenStateResume(t *testing.T) {
+	dir := t.TempDir()
+	s, _, err := openState(dir, stateStart, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.addTasks(
+		crawlTask{URL: stateStart},
+		crawlTask{URL: "https://example.com/a", Depth: 2},
+		crawlTask{URL: "https://example.com/done", Depth: 1},
+		crawlTask{URL: "https://example.com/b", Depth: 1},
+		// Та же страница найдена ближе к началу
+		crawlTask{URL: "https://example.com/a/", Depth: 1},
+		crawlTask{URL: "https://example.com/b", Depth: 3},
+	)
+	s.addResult(report.CrawlResult{URL: stateStart})
+	s.addResult(report.CrawlResult{URL: "https://example.com/done/"})
+	if err := s.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	resultsPath := filepath.Join(dir, stateResultsFile)
+	frontierPath := filepath.Join(dir, stateFrontierFile)
+	resultsSize, frontierSize := fileSize(t, resultsPath), fileSize(t, frontierPath)
+	appendRaw(t, resultsPath, `{"url":"https://example.com/b","rep`)
+	appendRaw(t, frontierPath, `{"url":"https://example.com/c","de`)
+
+	s, restored, err := openState(dir, stateStart, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.close()
+
+	if got := fileSize(t, resultsPath); got != resultsSize {
+		t.Errorf("results log size = %d, want torn line truncated to %d", got, resultsSize)
+	}
+	if got := fileSize(t, frontierPath); got != frontierSize {
+		t.Errorf("frontier log size = %d, want torn line truncated to %d", got, frontierSize)
+	}
+
+	var done []string
+	for _, res := range restored.results {
+		done = append(done, res.URL)
+	}
+	if want := []string{stateStart, "https://example.com/done/"}; !reflect.DeepEqual(done, want) {
+		t.Errorf("restored results = %v, want %v", done, want)
+	}
+	// Просканированные страницы не возвращаются в очередь, у повторов остаётся наименьшая глубина
+	want := []crawlTask{
+		{URL: "https://example.com/a", Depth: 1},
+		{URL: "https://example.com/b", Depth: 1},
+	}
+	if !reflect.DeepEqual(restored.tasks, want) {
+		t.Errorf("restored tasks = %+v, want %+v", restored.tasks, want)
+	}
+
+	// Новая запись после обрезки начинается с новой строки
+	s.addTasks(crawlTask{URL: "https://example.com/c", Depth: 2})
+	if err := s.close(); err != nil {
+		t.Fatal(err)
+	}
+	s, restored, err = openState(dir, stateStart, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.close()
+	if len(restored.tasks) != 3 || restored.tasks[2].URL != "https://example.com/c" {
+		t.Errorf("tasks after append = %+v, want /c queued last", restored.tasks)
+	}
+}
+
+func TestOpenStateResumeErrors(t *testing.T) {
+	if _, _, err := openState(t.TempDir(), stateStart, true); err == nil {
+		t.Error("resume without state: want error, got nil")
+	}
+
+	dir := t.TempDir()
+	s, _, err := openState(dir, stateStart, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := openState(dir, "https://other.example.com/", true); err == nil {
+		t.Error("resume with another start URL: want error, got nil")
+	}
+}
+
+func TestResumedSiteKeepsRuleCatalog(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><title>home</title></head><body><h1>home</h1></body></html>`)
+	}))
+	defer srv.Close()
+
+	reg := rules.Default()
+	if err := reg.SetEnabled("seo.title.missing", false); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	crawl := func(resume bool) string {
+		t.Helper()
+		c := NewCrawler(WithRules(reg), WithSitemaps(false), WithState(dir, resume))
+		site, err := c.CrawlSite(context.Background(), srv.URL+"/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := site.WriteJUnit(&buf); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	for name, out := range map[string]string{"fresh": crawl(false), "resumed": crawl(true)} {
+		if strings.Contains(out, `name="seo.title.missing"`) {
+			t.Errorf("%s crawl: disabled rule is reported as a test case", name)
+		}
+		if !strings.Contains(out, `name="seo.description.missing"`) {
+			t.Errorf("%s crawl: enabled rule is missing from the test cases", name)
+		}
+	}
+}
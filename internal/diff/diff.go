@@ -0,0 +1,171 @@
+package diff
+
+import (
+	"sort"
+
+	"bullwler/internal/baseline"
+	"bullwler/internal/report"
+)
+
+// Change — изменение значения между двумя аудитами
+type Change[T comparable] struct {
+	Old T `json:"old"`
+	New T `json:"new"`
+}
+
+func change[T comparable](oldV, newV T) *Change[T] {
+	if oldV == newV {
+		return nil
+	}
+	return &Change[T]{Old: oldV, New: newV}
+}
+
+// PageDiff — изменения одной страницы, присутствующей в обоих аудитах
+type PageDiff struct {
+	URL          string           `json:"url"`
+	StatusCode   *Change[int]     `json:"status_code,omitempty"`
+	Error        *Change[string]  `json:"error,omitempty"`
+	Title        *Change[string]  `json:"title,omitempty"`
+	Description  *Change[string]  `json:"description,omitempty"`
+	Canonical    *Change[string]  `json:"canonical,omitempty"`
+	AIScore      *Change[int]     `json:"ai_score,omitempty"`
+	AIScoreDelta int              `json:"ai_score_delta,omitempty"`
+	NewFindings  []report.Finding `json:"new_findings,omitempty"`
+	Resolved     []report.Finding `json:"resolved_findings,omitempty"`
+}
+
+// Empty — true, если страница не изменилась
+func (p PageDiff) Empty() bool {
+	return p.StatusCode == nil && p.Error == nil && p.Title == nil && p.Description == nil &&
+		p.Canonical == nil && p.AIScore == nil && len(p.NewFindings) == 0 && len(p.Resolved) == 0
+}
+
+// Result — сравнение двух аудитов сайта
+type Result struct {
+	OldURL        string     `json:"old_url"`
+	NewURL        string     `json:"new_url"`
+	AddedURLs     []string   `json:"added_urls"`
+	RemovedURLs   []string   `json:"removed_urls"`
+	Changed       []PageDiff `json:"changed_pages"`
+	NewFindings   int        `json:"new_findings"`
+	Resolved      int        `json:"resolved_findings"`
+	AvgAIScoreOld float64    `json:"avg_ai_score_old"`
+	AvgAIScoreNew float64    `json:"avg_ai_score_new"`
+}
+
+type pageState struct {
+	err    string
+	report *report.SEOReport
+}
+
+func collect(sr *report.SiteReport) map[string]pageState {
+	pages := make(map[string]pageState)
+	for _, res := range sr.SubReports {
+		st := pageState{report: res.Report}
+		if res.Error != nil {
+			st.err = res.Error.Error()
+		}
+		pages[res.URL] = st
+	}
+	if sr.MainReport != nil {
+		if _, ok := pages[sr.MainURL]; !ok {
+			pages[sr.MainURL] = pageState{report: sr.MainReport}
+		}
+	}
+	return pages
+}
+
+// Compare — сравнивает два сохранённых аудита
+func Compare(oldRep, newRep *report.SiteReport) *Result {
+	res := &Result{
+		OldURL:      oldRep.MainURL,
+		NewURL:      newRep.MainURL,
+		AddedURLs:   []string{},
+		RemovedURLs: []string{},
+		Changed:     []PageDiff{},
+	}
+	oldPages := collect(oldRep)
+	newPages := collect(newRep)
+
+	for u := range newPages {
+		if _, ok := oldPages[u]; !ok {
+			res.AddedURLs = append(res.AddedURLs, u)
+		}
+	}
+	for u := range oldPages {
+		if _, ok := newPages[u]; !ok {
+			res.RemovedURLs = append(res.RemovedURLs, u)
+		}
+	}
+	sort.Strings(res.AddedURLs)
+	sort.Strings(res.RemovedURLs)
+
+	var urls []string
+	for u := range newPages {
+		if _, ok := oldPages[u]; ok {
+			urls = append(urls, u)
+		}
+	}
+	sort.Strings(urls)
+
+	for _, u := range urls {
+		pd := comparePage(u, oldPages[u], newPages[u])
+		res.NewFindings += len(pd.NewFindings)
+		res.Resolved += len(pd.Resolved)
+		if !pd.Empty() {
+			res.Changed = append(res.Changed, pd)
+		}
+	}
+
+	res.AvgAIScoreOld = avgAIScore(oldRep)
+	res.AvgAIScoreNew = avgAIScore(newRep)
+	return res
+}
+
+func comparePage(u string, oldSt, newSt pageState) PageDiff {
+	pd := PageDiff{URL: u, Error: change(oldSt.err, newSt.err)}
+	o, n := oldSt.report, newSt.report
+	if o == nil || n == nil {
+		return pd
+	}
+
+	pd.StatusCode = change(o.StatusCode, n.StatusCode)
+	pd.Title = change(o.Title, n.Title)
+	pd.Description = change(o.Description, n.Description)
+	pd.Canonical = change(o.Canonical, n.Canonical)
+	pd.AIScore = change(o.AIScore, n.AIScore)
+	pd.AIScoreDelta = n.AIScore - o.AIScore
+	pd.NewFindings = subtract(u, n.Findings, o.Findings)
+	pd.Resolved = subtract(u, o.Findings, n.Findings)
+	return pd
+}
+
+// subtract — замечания из a, которых нет в b (с учётом кратности)
+func subtract(pageURL string, a, b []report.Finding) []report.Finding {
+	counts := make(map[string]int, len(b))
+	for _, f := range b {
+		counts[baseline.Fingerprint(pageURL, f)]++
+	}
+	var out []report.Finding
+	for _, f := range a {
+		fp := baseline.Fingerprint(pageURL, f)
+		if counts[fp] > 0 {
+			counts[fp]--
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+func avgAIScore(sr *report.SiteReport) float64 {
+	pages := sr.Reports()
+	if len(pages) == 0 {
+		return 0
+	}
+	total := 0
+	for _, p := range pages {
+		total += p.AIScore
+	}
+	return float64(total) / float64(len(pages))
+}
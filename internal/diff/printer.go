@@ -0,0 +1,102 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"bullwler/internal/i18n"
+
+	"github.com/fatih/color"
+)
+
+// WriteJSON — записывает результат сравнения в формате JSON
+func (r *Result) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(r)
+}
+
+// Fprint — выводит результат сравнения в терминальном виде
+func (r *Result) Fprint(w io.Writer) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	gray := color.New(color.FgHiBlack).SprintFunc()
+
+	fmt.Fprintln(w, cyan("\n🔀 "+i18n.T("print.diff.header")), r.NewURL)
+	fmt.Fprintln(w, strings.Repeat("─", 65))
+	fmt.Fprintf(w, "  %s\n", i18n.T("print.diff.findings",
+		red(fmt.Sprint(r.NewFindings)), green(fmt.Sprint(r.Resolved))))
+	fmt.Fprintf(w, "  %s\n", i18n.T("print.diff.avg-ai",
+		r.AvgAIScoreOld, r.AvgAIScoreNew, signedFloat(r.AvgAIScoreNew-r.AvgAIScoreOld)))
+
+	if len(r.AddedURLs) > 0 {
+		fmt.Fprintln(w, "\n"+cyan("➕ "+i18n.T("print.diff.added")))
+		for _, u := range r.AddedURLs {
+			fmt.Fprintf(w, "  %s\n", green(u))
+		}
+	}
+	if len(r.RemovedURLs) > 0 {
+		fmt.Fprintln(w, "\n"+cyan("➖ "+i18n.T("print.diff.removed")))
+		for _, u := range r.RemovedURLs {
+			fmt.Fprintf(w, "  %s\n", red(u))
+		}
+	}
+
+	if len(r.Changed) > 0 {
+		fmt.Fprintln(w, "\n"+cyan("✏️  "+i18n.T("print.diff.changed")))
+	}
+	for _, p := range r.Changed {
+		fmt.Fprintf(w, "\n  %s\n", p.URL)
+		if p.Error != nil {
+			fmt.Fprintf(w, "    %s: %q → %q\n", i18n.T("print.diff.error"), p.Error.Old, p.Error.New)
+		}
+		if p.StatusCode != nil {
+			fmt.Fprintf(w, "    %s: %d → %d\n", i18n.T("print.diff.status"), p.StatusCode.Old, p.StatusCode.New)
+		}
+		if p.Title != nil {
+			fmt.Fprintf(w, "    Title: %q → %q\n", p.Title.Old, p.Title.New)
+		}
+		if p.Description != nil {
+			fmt.Fprintf(w, "    Description: %q → %q\n", ellipsis(p.Description.Old, 60), ellipsis(p.Description.New, 60))
+		}
+		if p.Canonical != nil {
+			fmt.Fprintf(w, "    Canonical: %q → %q\n", p.Canonical.Old, p.Canonical.New)
+		}
+		if p.AIScore != nil {
+			fmt.Fprintf(w, "    AI Score: %d → %d%s\n", p.AIScore.Old, p.AIScore.New, signedFloat(float64(p.AIScoreDelta)))
+		}
+		for _, f := range p.NewFindings {
+			fmt.Fprintf(w, "    %s %s %s\n", red("+"), f.Message, gray("["+f.RuleID+"]"))
+		}
+		for _, f := range p.Resolved {
+			fmt.Fprintf(w, "    %s %s %s\n", green("−"), f.Message, gray("["+f.RuleID+"]"))
+		}
+	}
+
+	if len(r.AddedURLs) == 0 && len(r.RemovedURLs) == 0 && len(r.Changed) == 0 {
+		fmt.Fprintln(w, "\n"+green("✅ "+i18n.T("print.diff.none")))
+	}
+	fmt.Fprintln(w, "\n"+strings.Repeat("─", 65))
+}
+
+func signedFloat(d float64) string {
+	switch {
+	case d > 0:
+		return color.GreenString(" (+%g)", d)
+	case d < 0:
+		return color.RedString(" (%g)", d)
+	default:
+		return ""
+	}
+}
+
+func ellipsis(s string, maximum int) string {
+	if len(s) <= maximum {
+		return s
+	}
+	return s[:maximum-3] + "..."
+}
@@ -0,0 +1,161 @@
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"bullwler/internal/report"
+)
+
+// Type — тип события сканирования
+type Type string
+
+// Типы событий
+const (
+	// PageStartedType — начат анализ страницы
+	PageStartedType Type = "page_started"
+	// PageFinishedType — анализ страницы завершён
+	PageFinishedType Type = "page_finished"
+	// PageSkippedType — страница не анализировалась
+	PageSkippedType Type = "page_skipped"
+	// CrawlFinishedType — сканирование завершено
+	CrawlFinishedType Type = "crawl_finished"
+)
+
+// Причины пропуска страницы
+const (
+	ReasonRobots       = "robots"
+	ReasonMaxPages     = "max_pages"
+	ReasonMaxDepth     = "max_depth"
+	ReasonSectionPages = "section_pages"
+	ReasonScope        = "scope"
+	ReasonCanceled     = "canceled"
+)
+
+// Event — событие сканирования; поля заполняются в зависимости от типа
+type Event struct {
+	Type  Type      `json:"event"`
+	Time  time.Time `json:"time"`
+	URL   string    `json:"url,omitempty"`
+	Depth *int      `json:"depth,omitempty"`
+
+	// page_finished
+	Page     *PageSummary     `json:"page,omitempty"`
+	Findings []report.Finding `json:"findings,omitempty"`
+
+	// page_skipped
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+
+	// crawl_finished
+	Crawl *CrawlSummary `json:"crawl,omitempty"`
+}
+
+// PageSummary — краткие показатели проанализированной страницы
+type PageSummary struct {
+	StatusCode     int    `json:"status_code"`
+	ResponseTimeMs int64  `json:"response_time_ms"`
+	Title          string `json:"title"`
+	Canonical      string `json:"canonical"`
+	Errors         int    `json:"errors"`
+	Warnings       int    `json:"warnings"`
+	Info           int    `json:"info"`
+	AIScore        int    `json:"ai_score"`
+	AIMaxScore     int    `json:"ai_max_score"`
+	Links          int    `json:"links"`
+}
+
+// CrawlSummary — итог сканирования
+type CrawlSummary struct {
+	Pages      int    `json:"pages"`
+	Skipped    int    `json:"skipped"`
+	Errors     int    `json:"errors"`
+	Warnings   int    `json:"warnings"`
+	DurationMs int64  `json:"duration_ms"`
+	Stopped    string `json:"stopped,omitempty"`
+}
+
+// Handler — получатель событий; может вызываться из нескольких горутин одновременно
+type Handler func(Event)
+
+// PageStarted — событие начала анализа страницы
+func PageStarted(url string, depth int) Event {
+	return Event{Type: PageStartedType, Time: time.Now(), URL: url, Depth: &depth}
+}
+
+// PageFinished — событие завершения анализа страницы
+func PageFinished(url string, depth int, rep *report.SEOReport) Event {
+	return Event{
+		Type:  PageFinishedType,
+		Time:  time.Now(),
+		URL:   url,
+		Depth: &depth,
+		Page: &PageSummary{
+			StatusCode:     rep.StatusCode,
+			ResponseTimeMs: rep.ResponseTimeMs,
+			Title:          rep.Title,
+			Canonical:      rep.Canonical,
+			Errors:         rep.CountBySeverity(report.SeverityError),
+			Warnings:       rep.CountBySeverity(report.SeverityWarning),
+			Info:           rep.CountBySeverity(report.SeverityInfo),
+			AIScore:        rep.AIScore,
+			AIMaxScore:     rep.AIMaxScore,
+			Links:          len(rep.AllLinks),
+		},
+		Findings: rep.Findings,
+	}
+}
+
+// PageSkipped — событие пропуска страницы с машиночитаемой причиной reason
+func PageSkipped(url string, depth int, reason, message string) Event {
+	return Event{Type: PageSkippedType, Time: time.Now(), URL: url, Depth: &depth, Reason: reason, Message: message}
+}
+
+// CrawlFinished — событие завершения сканирования.
+// stopped — причина досрочной остановки (пусто, если сканирование завершилось само).
+func CrawlFinished(results []report.CrawlResult, started time.Time, stopped string) Event {
+	sum := &CrawlSummary{DurationMs: time.Since(started).Milliseconds(), Stopped: stopped}
+	for _, res := range results {
+		if res.Report == nil {
+			sum.Skipped++
+			continue
+		}
+		sum.Pages++
+		sum.Errors += res.Report.CountBySeverity(report.SeverityError)
+		sum.Warnings += res.Report.CountBySeverity(report.SeverityWarning)
+	}
+	return Event{Type: CrawlFinishedType, Time: time.Now(), Crawl: sum}
+}
+
+// Writer — пишет события в формате NDJSON: по одному JSON-объекту в строке
+type Writer struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+// NewWriter — создаёт NDJSON-писатель событий
+func NewWriter(w io.Writer) *Writer {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &Writer{enc: enc}
+}
+
+// Emit — записывает событие; первая ошибка записи сохраняется и возвращается Err
+func (w *Writer) Emit(e Event) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return
+	}
+	w.err = w.enc.Encode(e)
+}
+
+// Err — первая ошибка записи
+func (w *Writer) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
@@ -0,0 +1,93 @@
+package fetch
+
+import "strings"
+
+// Agent — идентичность краулера: с каким User-Agent отправляются запросы
+// и по какому токену выбираются правила robots.txt
+type Agent struct {
+	// Name — имя пресета; пусто для произвольного User-Agent
+	Name string `json:"name,omitempty"`
+	// Token — токен продукта, по которому ищется группа User-agent в robots.txt
+	Token string `json:"token"`
+	// UserAgent — значение заголовка User-Agent
+	UserAgent string `json:"user_agent"`
+}
+
+// DefaultAgent — имя пресета по умолчанию
+const DefaultAgent = "bullwler"
+
+// presets — известные поисковые и AI-краулеры. Строки User-Agent взяты из документации
+// владельцев краулеров; версия Chrome у Googlebot и Bingbot меняется вместе с их движком.
+var presets = []Agent{
+	{Name: DefaultAgent, Token: "BullwlerBot", UserAgent: DefaultUserAgent},
+	{Name: "googlebot-smartphone", Token: "Googlebot", UserAgent: "Mozilla/5.0 (Linux; Android 6.0.1; Nexus 5X Build/MMB29P) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/136.0.7103.92 Mobile Safari/537.36 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)"},
+	{Name: "googlebot-desktop", Token: "Googlebot", UserAgent: "Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko; compatible; Googlebot/2.1; +http://www.google.com/bot.html) Chrome/136.0.7103.92 Safari/537.36"},
+	{Name: "googlebot-image", Token: "Googlebot-Image", UserAgent: "Googlebot-Image/1.0"},
+	{Name: "google-extended", Token: "Google-Extended", UserAgent: "Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko; compatible; Googlebot/2.1; +http://www.google.com/bot.html) Chrome/136.0.7103.92 Safari/537.36"},
+	{Name: "bingbot", Token: "bingbot", UserAgent: "Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko; compatible; bingbot/2.0; +http://www.bing.com/bingbot.htm) Chrome/136.0.7103.92 Safari/537.36"},
+	{Name: "yandexbot", Token: "YandexBot", UserAgent: "Mozilla/5.0 (compatible; YandexBot/3.0; +http://yandex.com/bots)"},
+	{Name: "duckduckbot", Token: "DuckDuckBot", UserAgent: "DuckDuckBot/1.1; (+http://duckduckgo.com/duckduckbot.html)"},
+	{Name: "applebot", Token: "Applebot", UserAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Safari/605.1.15 (Applebot/0.1; +http://www.apple.com/go/applebot)"},
+	{Name: "gptbot", Token: "GPTBot", UserAgent: "Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko; compatible; GPTBot/1.2; +https://openai.com/gptbot)"},
+	{Name: "chatgpt-user", Token: "ChatGPT-User", UserAgent: "Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko); compatible; ChatGPT-User/1.0; +https://openai.com/bot"},
+	{Name: "oai-searchbot", Token: "OAI-SearchBot", UserAgent: "Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko); compatible; OAI-SearchBot/1.0; +https://openai.com/searchbot"},
+	{Name: "claudebot", Token: "ClaudeBot", UserAgent: "Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko; compatible; ClaudeBot/1.0; +claudebot@anthropic.com)"},
+	{Name: "claude-user", Token: "Claude-User", UserAgent: "Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko; compatible; Claude-User/1.0; +Claude-User@anthropic.com)"},
+	{Name: "perplexitybot", Token: "PerplexityBot", UserAgent: "Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko; compatible; PerplexityBot/1.0; +https://perplexity.ai/perplexitybot)"},
+	{Name: "ccbot", Token: "CCBot", UserAgent: "CCBot/2.0 (https://commoncrawl.org/faq/)"},
+}
+
+// Presets — список встроенных пресетов краулеров
+func Presets() []Agent {
+	return append([]Agent(nil), presets...)
+}
+
+// PresetNames — имена встроенных пресетов
+func PresetNames() []string {
+	names := make([]string, len(presets))
+	for i, p := range presets {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// LookupAgent — ищет пресет по имени без учёта регистра
+func LookupAgent(name string) (Agent, bool) {
+	for _, p := range presets {
+		if strings.EqualFold(p.Name, name) {
+			return p, true
+		}
+	}
+	return Agent{}, false
+}
+
+// ParseAgent — возвращает пресет по имени, а для произвольной строки — идентичность
+// с этим User-Agent и токеном robots.txt, извлечённым из строки. Пустая строка — пресет по умолчанию.
+func ParseAgent(value string) Agent {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		value = DefaultAgent
+	}
+	if a, ok := LookupAgent(value); ok {
+		return a
+	}
+	return Agent{Token: robotsToken(value), UserAgent: value}
+}
+
+// robotsToken — извлекает токен продукта из User-Agent: имя после "compatible;"
+// ("Mozilla/5.0 (compatible; YandexBot/3.0)" — YandexBot), иначе первое имя продукта
+// ("CCBot/2.0" — CCBot)
+func robotsToken(ua string) string {
+	s := ua
+	if i := strings.Index(strings.ToLower(s), "compatible;"); i >= 0 {
+		s = s[i+len("compatible;"):]
+	}
+	s = strings.TrimLeft(s, " (")
+	if i := strings.IndexAny(s, "/;() "); i >= 0 {
+		s = s[:i]
+	}
+	if s == "" {
+		return ua
+	}
+	return s
+}
@@ -0,0 +1,381 @@
+package fetch
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"bullwler/internal/i18n"
+)
+
+// DefaultUserAgent — User-Agent запросов по умолчанию
+const DefaultUserAgent = "Mozilla/5.0 (compatible; BullwlerBot/1.0)"
+
+// DefaultMaxBodySize — ограничение размера тела ответа по умолчанию, 10 МиБ
+const DefaultMaxBodySize = 10 << 20
+
+// defaultMaxConnsPerHost — сколько простаивающих соединений с одним хостом держать открытыми
+const defaultMaxConnsPerHost = 16
+
+// maxRedirects — сколько перенаправлений проходит запрос, как у net/http по умолчанию
+const maxRedirects = 10
+
+// Fetcher — общий HTTP-клиент всех подсистем: страниц, robots.txt, sitemap.xml и словаря schema.org.
+// Держит пул соединений и добавляет к каждому запросу User-Agent, а к запросам на проверяемые
+// хосты — ещё и заданные заголовки и cookie. Его идентичность (Agent) используется
+// и для запросов, и для проверки правил robots.txt.
+type Fetcher struct {
+	client      *http.Client
+	agent       Agent
+	headers     http.Header
+	cookies     []*http.Cookie
+	hosts       []string
+	maxBodySize int64
+}
+
+// Option — функциональная опция
+type Option func(*settings) error
+
+type settings struct {
+	client          *http.Client
+	agent           Agent
+	headers         http.Header
+	cookies         []*http.Cookie
+	hosts           []string
+	proxy           string
+	caFiles         []string
+	maxBodySize     int64
+	maxConnsPerHost int
+}
+
+// WithHTTPClient — использует готовый HTTP-клиент; опции прокси, сертификатов и пула
+// соединений к нему не применяются
+func WithHTTPClient(c *http.Client) Option {
+	return func(s *settings) error {
+		s.client = c
+		return nil
+	}
+}
+
+// WithUserAgent — задаёт идентичность краулера по имени пресета (googlebot-smartphone, gptbot и т. д.)
+// или строкой User-Agent; пустая строка оставляет текущее значение
+func WithUserAgent(ua string) Option {
+	return func(s *settings) error {
+		if ua != "" {
+			s.agent = ParseAgent(ua)
+		}
+		return nil
+	}
+}
+
+// WithAgent — задаёт идентичность краулера: User-Agent запросов и токен robots.txt
+func WithAgent(a Agent) Option {
+	return func(s *settings) error {
+		s.agent = a
+		return nil
+	}
+}
+
+// WithHeader — добавляет заголовок к запросам на хосты WithCredentialHosts
+func WithHeader(name, value string) Option {
+	return func(s *settings) error {
+		s.headers.Add(name, value)
+		return nil
+	}
+}
+
+// WithCookie — добавляет cookie к запросам на хосты WithCredentialHosts; raw — строка вида "name=value"
+func WithCookie(raw string) Option {
+	return func(s *settings) error {
+		cookies, err := http.ParseCookie(raw)
+		if err != nil {
+			return fmt.Errorf("%s: %w", i18n.T("err.fetch.cookie", raw), err)
+		}
+		s.cookies = append(s.cookies, cookies...)
+		return nil
+	}
+}
+
+// WithCredentialHosts — задаёт хосты, которым отправляются заголовки и cookie фетчера;
+// "*.example.com" — домен и все его поддомены. Запросы на остальные хосты (словарь schema.org,
+// внешние ресурсы, перенаправления на чужой сайт) уходят без них, чтобы токен тестового
+// стенда не попал третьим лицам.
+func WithCredentialHosts(hosts ...string) Option {
+	return func(s *settings) error {
+		for _, h := range hosts {
+			s.hosts = append(s.hosts, strings.ToLower(strings.TrimSpace(h)))
+		}
+		return nil
+	}
+}
+
+// WithProxy — задаёт прокси-сервер (http, https или socks5); по умолчанию
+// используются переменные окружения HTTP_PROXY, HTTPS_PROXY и NO_PROXY
+func WithProxy(rawURL string) Option {
+	return func(s *settings) error {
+		s.proxy = rawURL
+		return nil
+	}
+}
+
+// WithCAFile — добавляет к системным корневым сертификатам сертификаты из PEM-файла,
+// например CA тестового стенда
+func WithCAFile(path string) Option {
+	return func(s *settings) error {
+		s.caFiles = append(s.caFiles, path)
+		return nil
+	}
+}
+
+// WithMaxBodySize — ограничивает размер читаемого тела ответа; 0 — без ограничения
+func WithMaxBodySize(n int64) Option {
+	return func(s *settings) error {
+		s.maxBodySize = n
+		return nil
+	}
+}
+
+// WithMaxConnsPerHost — задаёт размер пула соединений с одним хостом
+func WithMaxConnsPerHost(n int) Option {
+	return func(s *settings) error {
+		if n > 0 {
+			s.maxConnsPerHost = n
+		}
+		return nil
+	}
+}
+
+// New — создаёт Fetcher
+func New(opts ...Option) (*Fetcher, error) {
+	s := settings{
+		agent:           ParseAgent(DefaultAgent),
+		headers:         make(http.Header),
+		maxBodySize:     DefaultMaxBodySize,
+		maxConnsPerHost: defaultMaxConnsPerHost,
+	}
+	for _, opt := range opts {
+		if err := opt(&s); err != nil {
+			return nil, err
+		}
+	}
+
+	client := s.client
+	if client == nil {
+		transport, err := s.transport()
+		if err != nil {
+			return nil, err
+		}
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return nil, err
+		}
+		client = &http.Client{Transport: transport, Jar: jar}
+	}
+
+	return &Fetcher{
+		client:      client,
+		agent:       s.agent,
+		headers:     s.headers,
+		cookies:     s.cookies,
+		hosts:       s.hosts,
+		maxBodySize: s.maxBodySize,
+	}, nil
+}
+
+func (s *settings) transport() (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConnsPerHost = s.maxConnsPerHost
+
+	if s.proxy != "" {
+		proxyURL, err := url.Parse(s.proxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, errors.New(i18n.T("err.fetch.proxy", s.proxy))
+		}
+		t.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if len(s.caFiles) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		for _, path := range s.caFiles {
+			pem, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", i18n.T("err.fetch.ca-read", path), err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, errors.New(i18n.T("err.fetch.ca-parse", path))
+			}
+		}
+		t.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return t, nil
+}
+
+var (
+	defaultOnce    sync.Once
+	defaultFetcher *Fetcher
+)
+
+// Default — общий Fetcher с настройками по умолчанию
+func Default() *Fetcher {
+	defaultOnce.Do(func() {
+		f, err := New()
+		if err != nil {
+			panic(err)
+		}
+		defaultFetcher = f
+	})
+	return defaultFetcher
+}
+
+// Agent — идентичность, с которой выполняются запросы и проверяется robots.txt
+func (f *Fetcher) Agent() Agent {
+	return f.agent
+}
+
+// Response — ответ сервера с прочитанным телом
+type Response struct {
+	// Response — заголовки и статус ответа; тело уже прочитано в Data и закрыто
+	*http.Response
+	// Data — тело ответа, не длиннее ограничения MaxBodySize
+	Data []byte
+	// Truncated — тело больше ограничения и прочитано не полностью
+	Truncated bool
+	// Redirects — адреса, по которым прошли перенаправления
+	Redirects []string
+	// Elapsed — время от отправки запроса до получения заголовков ответа
+	Elapsed time.Duration
+}
+
+// Get — загружает url и читает тело ответа с учётом ограничения размера
+func (f *Fetcher) Get(ctx context.Context, rawURL string) (*Response, error) {
+	req, err := f.NewRequest(ctx, http.MethodGet, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &Response{}
+	client := *f.client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if err := f.checkRedirect(req, via); err != nil {
+			return err
+		}
+		out.Redirects = append(out.Redirects, req.URL.String())
+		return nil
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	out.Response = resp
+	out.Elapsed = time.Since(start)
+
+	body := io.Reader(resp.Body)
+	if f.maxBodySize > 0 {
+		body = io.LimitReader(resp.Body, f.maxBodySize+1)
+	}
+	out.Data, err = io.ReadAll(body)
+	if err != nil {
+		return out, err
+	}
+	if f.maxBodySize > 0 && int64(len(out.Data)) > f.maxBodySize {
+		out.Data = out.Data[:f.maxBodySize]
+		out.Truncated = true
+	}
+	return out, nil
+}
+
+// Exists — проверяет, что ресурс отвечает кодом 200
+func (f *Fetcher) Exists(ctx context.Context, rawURL string) bool {
+	req, err := f.NewRequest(ctx, http.MethodGet, rawURL)
+	if err != nil {
+		return false
+	}
+	resp, err := f.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+// Do — выполняет запрос через пул соединений без ограничения размера тела;
+// нужен для потоковой обработки больших ответов. Тело закрывает вызывающий.
+func (f *Fetcher) Do(req *http.Request) (*http.Response, error) {
+	client := *f.client
+	client.CheckRedirect = f.checkRedirect
+	return client.Do(req)
+}
+
+// checkRedirect — политика перенаправлений поверх политики базового клиента: без неё
+// повторяет ограничение net/http по умолчанию, а при переходе на хост вне WithCredentialHosts
+// снимает заголовки и cookie фетчера, которые net/http копирует из исходного запроса
+func (f *Fetcher) checkRedirect(req *http.Request, via []*http.Request) error {
+	if f.client.CheckRedirect != nil {
+		if err := f.client.CheckRedirect(req, via); err != nil {
+			return err
+		}
+	} else if len(via) >= maxRedirects {
+		return errors.New(i18n.T("err.fetch.redirects", maxRedirects))
+	}
+	if !f.trusted(req.URL) {
+		for name := range f.headers {
+			req.Header.Del(name)
+		}
+		if len(f.cookies) > 0 {
+			req.Header.Del("Cookie")
+		}
+	}
+	return nil
+}
+
+// NewRequest — создаёт запрос с User-Agent фетчера; заголовки и cookie добавляются,
+// только если хост запроса входит в WithCredentialHosts
+func (f *Fetcher) NewRequest(ctx context.Context, method, rawURL string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if f.trusted(req.URL) {
+		for name, values := range f.headers {
+			for _, v := range values {
+				req.Header.Add(name, v)
+			}
+		}
+		for _, c := range f.cookies {
+			req.AddCookie(c)
+		}
+	}
+	req.Header.Set("User-Agent", f.agent.UserAgent)
+	return req, nil
+}
+
+// trusted — хост URL входит в WithCredentialHosts
+func (f *Fetcher) trusted(u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	for _, h := range f.hosts {
+		if domain, ok := strings.CutPrefix(h, "*."); ok {
+			if host == domain || strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		} else if host == h {
+			return true
+		}
+	}
+	return false
+}
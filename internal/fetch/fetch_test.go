@@ -0,0 +1,96 @@
+package fetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetStopsRedirectLoop(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	f, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Get(context.Background(), srv.URL+"/loop"); err == nil {
+		t.Fatal("redirect loop: want error, got nil")
+	}
+	if hits != maxRedirects {
+		t.Errorf("requests = %d, want %d", hits, maxRedirects)
+	}
+}
+
+func TestCredentialsStayOnAuditedHost(t *testing.T) {
+	var offHost http.Header
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offHost = r.Header.Clone()
+	}))
+	defer other.Close()
+
+	var onHost http.Header
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/away" {
+			http.Redirect(w, r, other.URL+"/landing", http.StatusFound)
+			return
+		}
+		onHost = r.Header.Clone()
+	}))
+	defer site.Close()
+
+	// Оба сервера слушают 127.0.0.1: проверяемый сайт адресуется как localhost
+	siteURL := strings.Replace(site.URL, "127.0.0.1", "localhost", 1)
+	f, err := New(
+		WithHeader("Authorization", "Bearer secret"),
+		WithHeader("X-Token", "secret"),
+		WithCookie("session=secret"),
+		WithCredentialHosts("localhost"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if _, err := f.Get(ctx, siteURL+"/"); err != nil {
+		t.Fatal(err)
+	}
+	if onHost.Get("Authorization") != "Bearer secret" || onHost.Get("Cookie") != "session=secret" {
+		t.Errorf("audited host: want credentials, got Authorization=%q Cookie=%q", onHost.Get("Authorization"), onHost.Get("Cookie"))
+	}
+
+	for name, get := range map[string]func() error{
+		"direct":   func() error { _, err := f.Get(ctx, other.URL+"/"); return err },
+		"redirect": func() error { _, err := f.Get(ctx, siteURL+"/away"); return err },
+		"do": func() error {
+			req, err := f.NewRequest(ctx, http.MethodGet, siteURL+"/away")
+			if err != nil {
+				return err
+			}
+			resp, err := f.Do(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+			return err
+		},
+	} {
+		offHost = nil
+		if err := get(); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if offHost == nil {
+			t.Fatalf("%s: off-host server was not requested", name)
+		}
+		for _, h := range []string{"Authorization", "X-Token", "Cookie"} {
+			if v := offHost.Get(h); v != "" {
+				t.Errorf("%s: off-host request has %s: %q", name, h, v)
+			}
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package gate
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"bullwler/internal/i18n"
+	"bullwler/internal/report"
+)
+
+type conditionKind int
+
+const (
+	kindSeverity conditionKind = iota
+	kindRule
+	kindMaxWarnings
+	kindMaxErrors
+	kindMinAIScore
+)
+
+// Condition — одно условие проверки качества
+type Condition struct {
+	Raw string
+
+	kind     conditionKind
+	severity report.Severity
+	ruleID   string
+	limit    int
+}
+
+// Gate — набор условий, при нарушении любого из которых аудит считается проваленным
+type Gate struct {
+	Conditions []Condition
+}
+
+// Breach — нарушенное условие
+type Breach struct {
+	Condition string `json:"condition"`
+	Message   string `json:"message"`
+}
+
+var (
+	ruleIDPattern  = regexp.MustCompile(`^[a-z0-9-]+(\.[a-z0-9-]+)+$`)
+	limitPattern   = regexp.MustCompile(`^([a-z-]+)\s*([<>])\s*(\d+)$`)
+	severityRank   = map[report.Severity]int{report.SeverityInfo: 1, report.SeverityWarning: 2, report.SeverityError: 3}
+	severityByName = map[string]report.Severity{
+		"error":   report.SeverityError,
+		"warning": report.SeverityWarning,
+		"info":    report.SeverityInfo,
+	}
+)
+
+// Parse — разбирает условия из строк вида "error", "seo.h1.missing",
+// "warnings>50", "errors>0", "ai-score<8". Каждая строка может содержать
+// несколько условий через запятую.
+func Parse(specs ...string) (*Gate, error) {
+	g := &Gate{}
+	for _, spec := range specs {
+		for _, raw := range strings.Split(spec, ",") {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				continue
+			}
+			c, err := parseCondition(raw)
+			if err != nil {
+				return nil, err
+			}
+			g.Conditions = append(g.Conditions, c)
+		}
+	}
+	return g, nil
+}
+
+func parseCondition(raw string) (Condition, error) {
+	c := Condition{Raw: raw}
+	lower := strings.ToLower(raw)
+
+	if sev, ok := severityByName[lower]; ok {
+		c.kind = kindSeverity
+		c.severity = sev
+		return c, nil
+	}
+
+	if m := limitPattern.FindStringSubmatch(lower); m != nil {
+		n, err := strconv.Atoi(m[3])
+		if err != nil {
+			return c, errors.New(i18n.T("err.gate.number", raw))
+		}
+		c.limit = n
+		switch {
+		case m[1] == "warnings" && m[2] == ">":
+			c.kind = kindMaxWarnings
+		case m[1] == "errors" && m[2] == ">":
+			c.kind = kindMaxErrors
+		case m[1] == "ai-score" && m[2] == "<":
+			c.kind = kindMinAIScore
+		default:
+			return c, errors.New(i18n.T("err.gate.limit", raw))
+		}
+		return c, nil
+	}
+
+	if ruleIDPattern.MatchString(raw) {
+		if _, ok := report.LookupRule(raw); !ok {
+			return c, errors.New(i18n.T("err.gate.rule", raw))
+		}
+		c.kind = kindRule
+		c.ruleID = raw
+		return c, nil
+	}
+
+	return c, errors.New(i18n.T("err.gate.parse", raw))
+}
+
+// Empty — true, если условия не заданы
+func (g *Gate) Empty() bool {
+	return g == nil || len(g.Conditions) == 0
+}
+
+// CheckPage — проверяет отчёт по одной странице
+func (g *Gate) CheckPage(r *report.SEOReport) []Breach {
+	return g.check([]*report.SEOReport{r}, nil)
+}
+
+// CheckSite — проверяет сводный отчёт по сайту
+func (g *Gate) CheckSite(sr *report.SiteReport) []Breach {
+	return g.check(sr.Reports(), sr.Failed())
+}
+
+func (g *Gate) check(pages []*report.SEOReport, failed []report.CrawlResult) []Breach {
+	if g.Empty() {
+		return nil
+	}
+
+	var breaches []Breach
+	for _, c := range g.Conditions {
+		if msg := c.evaluate(pages, failed); msg != "" {
+			breaches = append(breaches, Breach{Condition: c.Raw, Message: msg})
+		}
+	}
+	return breaches
+}
+
+func (c Condition) evaluate(pages []*report.SEOReport, failed []report.CrawlResult) string {
+	switch c.kind {
+	case kindSeverity:
+		count := 0
+		if severityRank[report.SeverityError] >= severityRank[c.severity] {
+			count += len(failed)
+		}
+		for _, p := range pages {
+			for _, f := range p.Findings {
+				if severityRank[f.Severity] >= severityRank[c.severity] {
+					count++
+				}
+			}
+		}
+		if count > 0 {
+			return i18n.T("gate.severity", c.severity, count)
+		}
+	case kindRule:
+		count, affected := 0, 0
+		for _, p := range pages {
+			n := len(p.FindingsWhere(func(f report.Finding) bool { return f.RuleID == c.ruleID }))
+			count += n
+			if n > 0 {
+				affected++
+			}
+		}
+		if count > 0 {
+			return i18n.T("gate.rule", c.ruleID, count, affected)
+		}
+	case kindMaxWarnings:
+		count := 0
+		for _, p := range pages {
+			count += p.CountBySeverity(report.SeverityWarning)
+		}
+		if count > c.limit {
+			return i18n.T("gate.max-warnings", count, c.limit)
+		}
+	case kindMaxErrors:
+		count := len(failed)
+		for _, p := range pages {
+			count += p.CountBySeverity(report.SeverityError)
+		}
+		if count > c.limit {
+			return i18n.T("gate.max-errors", count, c.limit)
+		}
+	case kindMinAIScore:
+		var low []string
+		for _, p := range pages {
+			if p.StatusCode == 200 && p.AIScore < c.limit {
+				low = append(low, fmt.Sprintf("%s (%d)", p.URL, p.AIScore))
+			}
+		}
+		if len(low) > 0 {
+			return i18n.T("gate.min-ai-score", c.limit, len(low), strings.Join(truncate(low, 5), ", "))
+		}
+	}
+	return ""
+}
+
+func truncate(items []string, n int) []string {
+	if len(items) <= n {
+		return items
+	}
+	out := append([]string{}, items[:n]...)
+	return append(out, i18n.T("gate.and-more", len(items)-n))
+}
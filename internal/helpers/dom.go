@@ -1,6 +1,8 @@
 package helpers
 
 import (
+	"strings"
+
 	"golang.org/x/net/html"
 )
 
@@ -98,3 +100,24 @@ func ExtractTypes(v any) []string {
 	}
 	return types
 }
+
+// maxSnippetLength - ограничение длины фрагмента разметки в замечаниях
+const maxSnippetLength = 200
+
+// StartTag - функция получения открывающего тега элемента в виде строки
+func StartTag(n *html.Node) string {
+	var b strings.Builder
+	b.WriteString("<" + n.Data)
+	for _, attr := range n.Attr {
+		b.WriteString(" " + attr.Key)
+		if attr.Val != "" {
+			b.WriteString("=\"" + html.EscapeString(attr.Val) + "\"")
+		}
+	}
+	b.WriteString(">")
+	s := b.String()
+	if len(s) > maxSnippetLength {
+		s = s[:maxSnippetLength-3] + "..."
+	}
+	return s
+}
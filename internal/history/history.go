@@ -0,0 +1,155 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"bullwler/internal/i18n"
+	"bullwler/internal/report"
+)
+
+// timeLayout — формат имени файла снимка (UTC)
+const timeLayout = "20060102T150405.000Z"
+
+// Store — история аудитов: директория снимков SiteReport, разложенных по сайтам
+type Store struct {
+	dir string
+}
+
+// Snapshot — сохранённый аудит сайта
+type Snapshot struct {
+	Site string    `json:"site"`
+	Time time.Time `json:"time"`
+	Path string    `json:"path"`
+}
+
+// SiteInfo — сайт, для которого есть сохранённые аудиты
+type SiteInfo struct {
+	Site      string    `json:"site"`
+	Snapshots int       `json:"snapshots"`
+	Last      time.Time `json:"last"`
+}
+
+// Open — открывает историю в директории dir; директория создаётся при первой записи
+func Open(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir — директория истории
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// SiteKey — ключ сайта в истории: хост без схемы, порт через подчёркивание
+func SiteKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return sanitize(rawURL)
+	}
+	return sanitize(strings.ToLower(u.Host))
+}
+
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
+
+// Save — сохраняет снимок аудита сайта с меткой времени at
+func (s *Store) Save(rep *report.SiteReport, at time.Time) (*Snapshot, error) {
+	site := SiteKey(rep.MainURL)
+	dir := filepath.Join(s.dir, site)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.history.mkdir"), err)
+	}
+
+	at = at.UTC()
+	path := filepath.Join(dir, at.Format(timeLayout)+".json")
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.history.save"), err)
+	}
+	if err := rep.WriteJSON(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.history.save"), err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.history.save"), err)
+	}
+	return &Snapshot{Site: site, Time: at, Path: path}, nil
+}
+
+// Sites — список сайтов в истории
+func (s *Store) Sites() (SiteList, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.history.read"), err)
+	}
+
+	var out SiteList
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		snaps, err := s.Snapshots(e.Name())
+		if err != nil {
+			return nil, err
+		}
+		if len(snaps) == 0 {
+			continue
+		}
+		out = append(out, SiteInfo{
+			Site:      e.Name(),
+			Snapshots: len(snaps),
+			Last:      snaps[len(snaps)-1].Time,
+		})
+	}
+	return out, nil
+}
+
+// Snapshots — снимки сайта в хронологическом порядке.
+// site — ключ сайта или URL.
+func (s *Store) Snapshots(site string) ([]Snapshot, error) {
+	site = SiteKey(site)
+	entries, err := os.ReadDir(filepath.Join(s.dir, site))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.history.read"), err)
+	}
+
+	var out []Snapshot
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		at, err := time.Parse(timeLayout, strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			continue
+		}
+		out = append(out, Snapshot{Site: site, Time: at, Path: filepath.Join(s.dir, site, name)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Time.Before(out[j].Time) })
+	return out, nil
+}
+
+// Load — читает отчёт снимка
+func (sn Snapshot) Load() (*report.SiteReport, error) {
+	return report.LoadJSON(sn.Path)
+}
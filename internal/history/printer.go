@@ -0,0 +1,121 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"bullwler/internal/i18n"
+	"bullwler/internal/report"
+
+	"github.com/fatih/color"
+)
+
+// topRulesShown — сколько самых частых правил показывать в динамике
+const topRulesShown = 5
+
+// WriteJSON — записывает динамику в формате JSON
+func (t *Trend) WriteJSON(w io.Writer) error {
+	return writeJSON(w, t)
+}
+
+// Fprint — выводит динамику показателей сайта
+func (t *Trend) Fprint(w io.Writer) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	gray := color.New(color.FgHiBlack).SprintFunc()
+
+	fmt.Fprintln(w, cyan("\n📈 "+i18n.T("print.history.header")+":"), t.Site)
+	fmt.Fprintln(w, strings.Repeat("─", 65))
+	if len(t.Points) == 0 {
+		fmt.Fprintln(w, "  "+i18n.T("print.history.empty"))
+		fmt.Fprintln(w, strings.Repeat("─", 65))
+		return
+	}
+
+	fmt.Fprintf(w, "  %-17s %7s %7s %8s %6s %6s %6s\n", i18n.T("print.history.col.date"), i18n.T("print.history.col.pages"), i18n.T("print.history.col.errors"),
+		i18n.T("print.history.col.warnings"), i18n.T("print.history.col.broken"), i18n.T("print.history.col.slow"), "AI")
+	for _, p := range t.Points {
+		fmt.Fprintf(w, "  %-17s %7d %7d %8d %6d %6d %6.1f\n",
+			p.Time.Local().Format("2006-01-02 15:04"), p.Pages, p.Errors, p.Warnings, p.BrokenPages, p.SlowPages, p.AvgAIScore)
+	}
+
+	first, last := t.Points[0], t.Points[len(t.Points)-1]
+	if len(t.Points) > 1 {
+		fmt.Fprintln(w, "\n"+cyan("📊 "+i18n.T("print.history.change")))
+		fmt.Fprintf(w, "  %s: %d → %d%s\n", i18n.T("print.history.pages"), first.Pages, last.Pages, delta(float64(last.Pages-first.Pages), false))
+		fmt.Fprintf(w, "  %s: %d → %d%s\n", i18n.T("print.history.errors"), first.Errors, last.Errors, delta(float64(last.Errors-first.Errors), true))
+		fmt.Fprintf(w, "  %s: %d → %d%s\n", i18n.T("print.history.warnings"), first.Warnings, last.Warnings, delta(float64(last.Warnings-first.Warnings), true))
+		fmt.Fprintf(w, "  %s: %d → %d%s\n", i18n.T("print.history.broken"), first.BrokenPages, last.BrokenPages, delta(float64(last.BrokenPages-first.BrokenPages), true))
+		fmt.Fprintf(w, "  %s: %d → %d%s\n", i18n.T("print.history.slow"), first.SlowPages, last.SlowPages, delta(float64(last.SlowPages-first.SlowPages), true))
+		fmt.Fprintf(w, "  %s: %.1f → %.1f%s\n", i18n.T("print.history.avg-ai"), first.AvgAIScore, last.AvgAIScore, delta(last.AvgAIScore-first.AvgAIScore, false))
+	}
+
+	if len(last.Rules) > 0 {
+		fmt.Fprintln(w, "\n"+cyan("📉 "+i18n.T("print.history.top-rules"))+" "+gray("("+i18n.T("print.history.top-rules.hint")+")"))
+		for i, rc := range last.Rules {
+			if i == topRulesShown {
+				break
+			}
+			title := rc.RuleID
+			if info, ok := report.LookupRule(rc.RuleID); ok && info.Title != "" {
+				title = info.Title
+			}
+			series := t.RuleSeries(rc.RuleID)
+			parts := make([]string, len(series))
+			for j, n := range series {
+				parts[j] = fmt.Sprint(n)
+			}
+			fmt.Fprintf(w, "  • %s %s\n    %s\n", title, gray("["+rc.RuleID+"]"), strings.Join(parts, " → "))
+		}
+	}
+	fmt.Fprintln(w, strings.Repeat("─", 65))
+}
+
+// delta — изменение показателя; lowerIsBetter задаёт, какое направление считать улучшением
+func delta(d float64, lowerIsBetter bool) string {
+	if d == 0 {
+		return ""
+	}
+	good := d > 0
+	if lowerIsBetter {
+		good = !good
+	}
+	text := fmt.Sprintf(" (%+g)", d)
+	if good {
+		return color.GreenString(text)
+	}
+	return color.RedString(text)
+}
+
+// SiteList — список сайтов в истории
+type SiteList []SiteInfo
+
+// WriteJSON — записывает список сайтов в формате JSON
+func (l SiteList) WriteJSON(w io.Writer) error {
+	if l == nil {
+		l = SiteList{}
+	}
+	return writeJSON(w, l)
+}
+
+// Fprint — выводит список сайтов
+func (l SiteList) Fprint(w io.Writer) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	fmt.Fprintln(w, cyan("\n📈 "+i18n.T("print.history.header")))
+	fmt.Fprintln(w, strings.Repeat("─", 65))
+	if len(l) == 0 {
+		fmt.Fprintln(w, "  "+i18n.T("print.history.empty"))
+	}
+	for _, s := range l {
+		fmt.Fprintf(w, "  %-35s %s\n", s.Site, i18n.T("print.history.site", s.Snapshots, s.Last.Local().Format("2006-01-02 15:04")))
+	}
+	fmt.Fprintln(w, strings.Repeat("─", 65))
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
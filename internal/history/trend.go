@@ -0,0 +1,94 @@
+package history
+
+import (
+	"fmt"
+	"time"
+
+	"bullwler/internal/i18n"
+	"bullwler/internal/report"
+)
+
+// slowRuleID — правило медленного ответа сервера
+const slowRuleID = "performance.response.slow"
+
+// Point — показатели сайта в одном снимке
+type Point struct {
+	Time        time.Time          `json:"time"`
+	Pages       int                `json:"pages"`
+	Errors      int                `json:"errors"`
+	Warnings    int                `json:"warnings"`
+	BrokenPages int                `json:"broken_pages"`
+	SlowPages   int                `json:"slow_pages"`
+	AvgAIScore  float64            `json:"avg_ai_score"`
+	Rules       []report.RuleCount `json:"rules"`
+}
+
+// Trend — динамика показателей сайта
+type Trend struct {
+	Site   string  `json:"site"`
+	Points []Point `json:"points"`
+}
+
+// NewPoint — считает показатели одного аудита
+func NewPoint(at time.Time, rep *report.SiteReport, topRules int) Point {
+	sum := rep.Summary()
+	p := Point{
+		Time:        at,
+		Pages:       sum.Pages,
+		Errors:      sum.Errors,
+		Warnings:    sum.Warnings,
+		BrokenPages: sum.BrokenPages,
+		SlowPages:   sum.Rules[slowRuleID].Pages,
+	}
+
+	pages := rep.Reports()
+	if len(pages) > 0 {
+		total := 0
+		for _, r := range pages {
+			total += r.AIScore
+		}
+		p.AvgAIScore = float64(total) / float64(len(pages))
+	}
+
+	p.Rules = sum.TopRules()
+	if topRules >= 0 && len(p.Rules) > topRules {
+		p.Rules = p.Rules[:topRules]
+	}
+	return p
+}
+
+// Trend — строит динамику по последним limit снимкам сайта (все при limit <= 0)
+func (s *Store) Trend(site string, limit int) (*Trend, error) {
+	snaps, err := s.Snapshots(site)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(snaps) > limit {
+		snaps = snaps[len(snaps)-limit:]
+	}
+
+	t := &Trend{Site: SiteKey(site), Points: []Point{}}
+	for _, sn := range snaps {
+		rep, err := sn.Load()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", i18n.T("err.history.snapshot", sn.Path), err)
+		}
+		// Правила храним целиком, чтобы сравнивать частоту между снимками
+		t.Points = append(t.Points, NewPoint(sn.Time, rep, -1))
+	}
+	return t, nil
+}
+
+// RuleSeries — число страниц с замечанием правила в каждом снимке
+func (t *Trend) RuleSeries(ruleID string) []int {
+	out := make([]int, len(t.Points))
+	for i, p := range t.Points {
+		for _, rc := range p.Rules {
+			if rc.RuleID == ruleID {
+				out[i] = rc.Pages
+				break
+			}
+		}
+	}
+	return out
+}
@@ -2,11 +2,11 @@ package htmlparser
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/url"
 	"strings"
 
 	"bullwler/internal/helpers"
+	"bullwler/internal/i18n"
 	"bullwler/internal/report"
 
 	"golang.org/x/net/html"
@@ -45,9 +45,11 @@ func processElement(n *html.Node, r *report.SEOReport, labelForMap map[string]bo
 	case "link":
 		if rel := helpers.GetAttr(n, "rel"); rel == "canonical" {
 			r.HasCanonical = true
-			if u, err := url.Parse(helpers.GetAttr(n, "href")); err == nil {
+			href := helpers.GetAttr(n, "href")
+			if u, err := url.Parse(href); err == nil {
 				r.CanonicalHost = u.Host
 			}
+			r.Canonical = resolveURL(r.URL, href)
 		}
 	case "script":
 		handleScript(n, r)
@@ -94,7 +96,7 @@ func processElement(n *html.Node, r *report.SEOReport, labelForMap map[string]bo
 	}
 
 	if helpers.HasAttr(n, "onclick") && !helpers.HasAttr(n, "tabindex") {
-		r.A11yWarnings = append(r.A11yWarnings, "<div> с onclick должен иметь tabindex=\"0\" для клавиатурной навигации")
+		r.AddFinding("a11y.onclick.tabindex", i18n.T("msg.a11y.onclick.tabindex", tag), elementEvidence(n, "onclick"))
 	}
 
 	if idVal, hasID := helpers.GetAttrExists(n, "id"); hasID && idVal != "" {
@@ -112,18 +114,18 @@ func processElement(n *html.Node, r *report.SEOReport, labelForMap map[string]bo
 			targets := strings.Fields(attr.Val)
 			for _, targetID := range targets {
 				if !idExists(r.AllIDs, targetID) {
-					r.A11yErrors = append(r.A11yErrors, fmt.Sprintf("aria-labelledby='%s' ссылается на несуществующий id", targetID))
+					r.AddFinding("a11y.aria-labelledby.broken", i18n.T("msg.a11y.aria-labelledby.broken", targetID), elementEvidence(n, "aria-labelledby"))
 				}
 			}
 		case "role":
 			r.Roles++
 			if !isValidRoleForElement(tag, attr.Val) {
-				r.A11yWarnings = append(r.A11yWarnings, fmt.Sprintf("Недопустимая роль '%s' для <%s>", attr.Val, tag))
+				r.AddFinding("a11y.role.invalid", i18n.T("msg.a11y.role.invalid", attr.Val, tag), elementEvidence(n, "role"))
 			}
 			required := requiredAriaAttrs(attr.Val)
 			for _, reqAttr := range required {
 				if !helpers.HasAttr(n, reqAttr) {
-					r.A11yErrors = append(r.A11yErrors, fmt.Sprintf("Роль '%s' требует атрибут %s", attr.Val, reqAttr))
+					r.AddFinding("a11y.role.required-attr", i18n.T("msg.a11y.role.required-attr", attr.Val, reqAttr), elementEvidence(n, reqAttr))
 				}
 			}
 		}
@@ -170,6 +172,9 @@ func handleMeta(n *html.Node, r *report.SEOReport) {
 	if name == "viewport" {
 		r.HasViewport = true
 	}
+	if strings.EqualFold(name, "robots") {
+		r.MetaRobots = content
+	}
 }
 
 func handleScript(n *html.Node, r *report.SEOReport) {
@@ -178,19 +183,19 @@ func handleScript(n *html.Node, r *report.SEOReport) {
 		content := strings.Join(helpers.CollectText(n), "")
 		trimmed := strings.TrimSpace(content)
 		if trimmed == "" {
-			r.SchemaOrgErrors = append(r.SchemaOrgErrors, "Пустой JSON-LD блок")
+			r.SchemaOrgErrors = append(r.SchemaOrgErrors, i18n.T("msg.schema.empty"))
 			return
 		}
 
 		var data map[string]interface{}
 		if err := json.Unmarshal([]byte(trimmed), &data); err != nil {
-			r.SchemaOrgErrors = append(r.SchemaOrgErrors, "Некорректный JSON: "+err.Error())
+			r.SchemaOrgErrors = append(r.SchemaOrgErrors, i18n.T("msg.schema.invalid-json", err))
 			return
 		}
 
 		context, hasContext := data["@context"]
 		if !hasContext {
-			r.SchemaOrgErrors = append(r.SchemaOrgErrors, "Отсутствует @context")
+			r.SchemaOrgErrors = append(r.SchemaOrgErrors, i18n.T("msg.schema.context-missing"))
 		} else {
 			ctxStr := ""
 			switch v := context.(type) {
@@ -204,7 +209,7 @@ func handleScript(n *html.Node, r *report.SEOReport) {
 				}
 			}
 			if ctxStr != "https://schema.org" && ctxStr != "http://schema.org" {
-				r.SchemaOrgErrors = append(r.SchemaOrgErrors, "@context должен быть 'https://schema.org'")
+				r.SchemaOrgErrors = append(r.SchemaOrgErrors, i18n.T("msg.schema.context-invalid"))
 			}
 		}
 
@@ -212,11 +217,11 @@ func handleScript(n *html.Node, r *report.SEOReport) {
 			types := helpers.ExtractTypes(typeVal)
 			for _, t := range types {
 				if !r.SchemaTypes[t] {
-					r.SchemaOrgErrors = append(r.SchemaOrgErrors, fmt.Sprintf("Неизвестный тип Schema.org: %s", t))
+					r.SchemaOrgErrors = append(r.SchemaOrgErrors, i18n.T("msg.schema.unknown-type", t))
 				}
 			}
 		} else {
-			r.SchemaOrgErrors = append(r.SchemaOrgErrors, "Отсутствует @type")
+			r.SchemaOrgErrors = append(r.SchemaOrgErrors, i18n.T("msg.schema.type-missing"))
 		}
 
 		r.JSONLD = append(r.JSONLD, data)
@@ -229,13 +234,13 @@ func handleImage(n *html.Node, r *report.SEOReport) {
 
 	if !hasAlt {
 		r.ImageWithoutAlt++
-		r.A11yErrors = append(r.A11yErrors, "Изображение без alt-атрибута")
+		r.AddFinding("a11y.img.alt.missing", i18n.T("msg.a11y.img.alt.missing"), elementEvidence(n, "alt"))
 	} else if alt == "" {
 		r.ImageWithEmptyAlt++
 	} else {
 		lower := strings.ToLower(alt)
 		if strings.Contains(lower, "изображение") || strings.Contains(lower, "image") || strings.Contains(lower, "img") {
-			r.A11yWarnings = append(r.A11yWarnings, fmt.Sprintf("Бесполезный alt: '%s'", alt))
+			r.AddFinding("a11y.img.alt.useless", i18n.T("msg.a11y.img.alt.useless", alt), elementEvidence(n, "alt"))
 		}
 	}
 }
@@ -309,11 +314,19 @@ func handleInput(n *html.Node, r *report.SEOReport, labelForMap map[string]bool)
 			r.RequiredWithoutLabel++
 		}
 		if !helpers.HasAttr(n, "aria-label") && !helpers.HasAttr(n, "aria-labelledby") {
-			r.A11yErrors = append(r.A11yErrors, "Поле ввода не имеет доступной метки (ни <label>, ни aria-label)")
+			r.AddFinding("a11y.input.label.missing", i18n.T("msg.a11y.input.label.missing"), elementEvidence(n, "aria-label"))
 		}
 	}
 }
 
+func elementEvidence(n *html.Node, attr string) *report.Evidence {
+	return &report.Evidence{
+		Element:   n.Data,
+		Attribute: attr,
+		Snippet:   helpers.StartTag(n),
+	}
+}
+
 func extractSchemaTypes(itemtype string) []string {
 	var types []string
 	for _, part := range strings.Split(itemtype, " ") {
@@ -491,7 +504,7 @@ func ValidateHeadings(r *report.SEOReport) bool {
 	return true
 }
 
-// CheckAIFeatures - расширенный анализ на ИИ-дружелюбность
+// CheckAIFeatures - извлекает из JSON-LD сведения о датах и авторе для оценки ИИ-дружелюбности
 func CheckAIFeatures(r *report.SEOReport) {
 	for _, ld := range r.JSONLD {
 		if _, has := ld["datePublished"]; has {
@@ -510,164 +523,5 @@ func CheckAIFeatures(r *report.SEOReport) {
 		}
 	}
 
-	score := 0
-
-	if r.HasMain {
-		score++
-	}
-	if r.HasJSONLD && len(r.SchemaOrgErrors) == 0 {
-		score++
-	}
-	if r.Description != "" || len(r.HeadingTexts["h1"]) > 0 {
-		score++
-	}
-	if r.HasCanonical {
-		score++
-	}
-
-	if r.HasDatePublished || r.HasDateModified {
-		score++
-	}
-	if r.HasAuthorWithName {
-		score++
-	}
-
-	if r.TextBytes > 500 {
-		score++
-	}
-	if r.TextToHTMLRatio > 0.1 {
-		score++
-	}
-	if r.TextToHTMLRatio > 0.2 {
-		score++
-	}
-
-	if r.ListCount > 0 || r.TableCount > 0 {
-		score++
-	}
-
-	if r.HTMLLang != "" {
-		score++
-	}
-	if r.HasDirectAnswer {
-		score++
-	}
-	if r.TextDensityScore > 0.6 {
-		score++
-	}
-	if r.HasFAQStructured || r.HasHowToStructured {
-		score++
-	}
-
-	r.AIScore = score
-}
-
-// AddWarnings - функция для добавления предупреждений в отчет
-func AddWarnings(r *report.SEOReport) {
-	if r.TitleLength == 0 {
-		r.Warnings = append(r.Warnings, "Отсутствует <title>")
-	} else if r.TitleLength > 60 {
-		r.Warnings = append(r.Warnings, "Title слишком длинный (>60 символов)")
-	}
-	if r.DescriptionLength == 0 {
-		r.Warnings = append(r.Warnings, "Отсутствует meta description")
-	} else if r.DescriptionLength > 160 {
-		r.Warnings = append(r.Warnings, "Description слишком длинный (>160 символов)")
-	}
-	if !r.HasViewport {
-		r.Warnings = append(r.Warnings, "Отсутствует <meta name=\"viewport\">")
-	}
-	if r.HeadingCounts["h1"] == 0 {
-		r.Warnings = append(r.Warnings, "Отсутствует <h1>")
-	} else if r.HeadingCounts["h1"] > 1 {
-		r.Warnings = append(r.Warnings, "Несколько <h1>")
-	}
-	if !r.HasMain {
-		r.Warnings = append(r.Warnings, "Отсутствует <main>")
-	}
-
-	if len(r.OG) == 0 {
-		r.Info = append(r.Info, "Отсутствует Open Graph разметка")
-	} else {
-		missing := []string{}
-		for _, k := range []string{"title", "description", "image"} {
-			if r.OG[k] == "" {
-				missing = append(missing, k)
-			}
-		}
-		if len(missing) > 0 {
-			r.Info = append(r.Info, "Open Graph: отсутствуют поля "+strings.Join(missing, ", "))
-		}
-	}
-
-	if len(r.Twitter) == 0 {
-		r.Info = append(r.Info, "Отсутствует Twitter Card разметка")
-	} else if r.Twitter["card"] == "" {
-		r.Info = append(r.Info, "Twitter Card: отсутствует twitter:card")
-	}
-
-	if !r.HasJSONLD && !r.HasMicrodata && !r.HasRDFa {
-		r.Warnings = append(r.Warnings, "Отсутствуют структурированные данные (Schema.org)")
-	}
-
-	if len(r.SchemaOrgErrors) > 0 {
-		r.Warnings = append(r.Warnings, "Ошибки Schema.org: "+strings.Join(r.SchemaOrgErrors, "; "))
-	} else if r.HasJSONLD {
-		r.SchemaOrgValidationOK = true
-	}
-
-	if r.ImageWithoutAlt > 0 {
-		r.Warnings = append(r.Warnings, fmt.Sprintf("%d изображений без alt-атрибута", r.ImageWithoutAlt))
-	}
-	if r.InputWithoutLabel > 0 {
-		r.Warnings = append(r.Warnings, fmt.Sprintf("%d полей без <label>", r.InputWithoutLabel))
-	}
-	if r.InputWithoutName > 0 {
-		r.Warnings = append(r.Warnings, fmt.Sprintf("%d полей без name", r.InputWithoutName))
-	}
-
-	if !r.IsHTTPS {
-		r.Warnings = append(r.Warnings, "Сайт не использует HTTPS")
-	}
-	if r.ResponseTimeMs > 3000 {
-		r.Warnings = append(r.Warnings, fmt.Sprintf("Медленная загрузка: %d мс", r.ResponseTimeMs))
-	}
-	if !r.HasRobotsTxt {
-		r.Info = append(r.Info, "Отсутствует robots.txt")
-	}
-	if !r.HasSitemap {
-		r.Info = append(r.Info, "Отсутствует sitemap.xml")
-	}
-	if len(r.Redirects) > 0 {
-		r.Info = append(r.Info, fmt.Sprintf("Цепочка редиректов: %d шагов", len(r.Redirects)))
-	}
-
-	if r.InsecureExternalLinks > 0 {
-		r.Warnings = append(r.Warnings, fmt.Sprintf("%d ссылок с target=\"_blank\" без rel=\"noopener noreferrer\"", r.InsecureExternalLinks))
-	}
-	if r.InsecureResources > 0 {
-		r.Warnings = append(r.Warnings, fmt.Sprintf("%d небезопасных ресурсов (HTTP) на HTTPS-странице", r.InsecureResources))
-	}
-	if len(r.MissingSecurityHeaders) > 0 {
-		r.Warnings = append(r.Warnings, "Отсутствуют заголовки безопасности: "+strings.Join(r.MissingSecurityHeaders, ", "))
-	}
-	if r.FormsWithGetMethod > 0 {
-		r.Info = append(r.Info, fmt.Sprintf("%d форм используют method=\"get\"", r.FormsWithGetMethod))
-	}
-	if r.InsecureFormActions > 0 {
-		r.Warnings = append(r.Warnings, "Формы отправляют данные по HTTP на HTTPS-сайте")
-	}
-
-	if r.TextToHTMLRatio < 0.05 {
-		r.Warnings = append(r.Warnings, "Низкое соотношение текста к HTML (<5%) — ИИ может не распознать основной контент")
-	}
-	if !r.HasDatePublished {
-		r.Info = append(r.Info, "Отсутствует datePublished — ИИ не сможет определить актуальность")
-	}
-	if !r.HasDirectAnswer && strings.HasSuffix(strings.TrimSpace(r.Title), "?") {
-		r.Info = append(r.Info, "Заголовок-вопрос не содержит прямого ответа в тексте")
-	}
-	if r.TextDensityScore < 0.4 {
-		r.Warnings = append(r.Warnings, "Высокая доля 'воды' в тексте — ИИ может проигнорировать")
-	}
+	r.SchemaOrgValidationOK = r.HasJSONLD && len(r.SchemaOrgErrors) == 0
 }
@@ -0,0 +1,168 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Встроенные языки
+const (
+	Russian = "ru"
+	English = "en"
+)
+
+// Default — язык по умолчанию, если язык окружения не поддерживается
+const Default = Russian
+
+//go:embed locales/*.json
+var builtin embed.FS
+
+var (
+	mu       sync.RWMutex
+	catalogs = make(map[string]map[string]string)
+	current  = Default
+)
+
+func init() {
+	entries, err := builtin.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	for _, e := range entries {
+		data, err := builtin.ReadFile("locales/" + e.Name())
+		if err != nil {
+			panic(err)
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: %s: %v", e.Name(), err))
+		}
+		Register(strings.TrimSuffix(e.Name(), ".json"), messages)
+	}
+}
+
+// Register — добавляет сообщения в каталог языка lang; существующие ключи перезаписываются
+func Register(lang string, messages map[string]string) {
+	mu.Lock()
+	defer mu.Unlock()
+	cat, ok := catalogs[lang]
+	if !ok {
+		cat = make(map[string]string, len(messages))
+		catalogs[lang] = cat
+	}
+	for k, v := range messages {
+		cat[k] = v
+	}
+}
+
+// LoadFile — загружает каталог из JSON-файла вида {"ключ": "сообщение"}.
+// Код языка берётся из имени файла: de.json → de. Возвращает код языка.
+func LoadFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", T("err.i18n.read"), err)
+	}
+	var messages map[string]string
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return "", fmt.Errorf("%s: %w", T("err.i18n.parse", path), err)
+	}
+	lang := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	Register(lang, messages)
+	return lang, nil
+}
+
+// SetLang — выбирает язык сообщений
+func SetLang(lang string) error {
+	mu.Lock()
+	_, ok := catalogs[lang]
+	if ok {
+		current = lang
+	}
+	mu.Unlock()
+	if !ok {
+		return errors.New(T("err.i18n.unsupported", lang, strings.Join(Available(), ", ")))
+	}
+	return nil
+}
+
+// Lang — текущий язык сообщений
+func Lang() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Available — коды языков с загруженными каталогами
+func Available() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return available()
+}
+
+func available() []string {
+	out := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		out = append(out, lang)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Detect — определяет язык по переменным окружения LC_ALL, LC_MESSAGES и LANG.
+// Возвращает пустую строку, если язык не задан или не поддерживается.
+func Detect(getenv func(string) string) string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := getenv(name)
+		if v == "" {
+			continue
+		}
+		// ru_RU.UTF-8 → ru; C и POSIX означают отсутствие локали
+		lang := strings.ToLower(v)
+		if i := strings.IndexAny(lang, "_.@-"); i >= 0 {
+			lang = lang[:i]
+		}
+		if lang == "c" || lang == "posix" {
+			return ""
+		}
+		mu.RLock()
+		_, ok := catalogs[lang]
+		mu.RUnlock()
+		if ok {
+			return lang
+		}
+		return ""
+	}
+	return ""
+}
+
+// Lookup — сообщение по ключу на текущем языке с откатом на английский и русский
+func Lookup(key string) (string, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, lang := range []string{current, English, Russian} {
+		if msg, ok := catalogs[lang][key]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// T — переводит сообщение key и подставляет аргументы по правилам fmt.Sprintf.
+// Неизвестный ключ возвращается как есть, чтобы пропуск перевода был заметен.
+func T(key string, args ...any) string {
+	msg, ok := Lookup(key)
+	if !ok {
+		msg = key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// pageColumns — столбцы плоской выгрузки страниц
+var pageColumns = []string{
+	"url", "status_code", "response_time_ms",
+	"title", "title_length", "description", "description_length",
+	"h1_count", "canonical", "html_lang", "text_to_html_ratio", "ai_score",
+	"image_count", "images_without_alt", "images_with_empty_alt",
+	"errors", "warnings", "info", "skip_reason",
+}
+
+// findingColumns — столбцы выгрузки замечаний
+var findingColumns = []string{
+	"url", "rule_id", "category", "severity", "message",
+	"element", "attribute", "snippet", "remediation",
+}
+
+// WriteCSV — записывает строку показателей страницы; comma — разделитель (',' или '\t')
+func (r *SEOReport) WriteCSV(w io.Writer, comma rune) error {
+	return r.asSite().WriteCSV(w, comma)
+}
+
+// WriteFindingsCSV — записывает замечания страницы, по одному в строке
+func (r *SEOReport) WriteFindingsCSV(w io.Writer, comma rune) error {
+	return r.asSite().WriteFindingsCSV(w, comma)
+}
+
+// WriteCSV — записывает показатели сайта, по строке на каждый просканированный URL.
+// Пропущенные страницы попадают в выгрузку с причиной в столбце skip_reason.
+func (sr *SiteReport) WriteCSV(w io.Writer, comma rune) error {
+	cw := newCSVWriter(w, comma)
+	if err := cw.Write(pageColumns); err != nil {
+		return err
+	}
+	for _, res := range sr.results() {
+		if res.Report == nil {
+			row := make([]string, len(pageColumns))
+			row[0] = res.URL
+			if res.Error != nil {
+				row[len(row)-1] = res.Error.Error()
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := cw.Write(pageRow(res.Report)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteFindingsCSV — записывает все замечания сайта, по одному в строке
+func (sr *SiteReport) WriteFindingsCSV(w io.Writer, comma rune) error {
+	cw := newCSVWriter(w, comma)
+	if err := cw.Write(findingColumns); err != nil {
+		return err
+	}
+	for _, r := range sr.Reports() {
+		for _, f := range r.Findings {
+			var ev Evidence
+			if f.Evidence != nil {
+				ev = *f.Evidence
+			}
+			row := []string{
+				r.URL, f.RuleID, string(f.Category), string(f.Severity), f.Message,
+				ev.Element, ev.Attribute, ev.Snippet, f.Remediation,
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func pageRow(r *SEOReport) []string {
+	return []string{
+		r.URL,
+		strconv.Itoa(r.StatusCode),
+		strconv.FormatInt(r.ResponseTimeMs, 10),
+		r.Title,
+		strconv.Itoa(r.TitleLength),
+		r.Description,
+		strconv.Itoa(r.DescriptionLength),
+		strconv.Itoa(r.HeadingCounts["h1"]),
+		r.Canonical,
+		r.HTMLLang,
+		strconv.FormatFloat(r.TextToHTMLRatio, 'f', 4, 64),
+		strconv.Itoa(r.AIScore),
+		strconv.Itoa(r.ImageCount),
+		strconv.Itoa(r.ImageWithoutAlt),
+		strconv.Itoa(r.ImageWithEmptyAlt),
+		strconv.Itoa(r.CountBySeverity(SeverityError)),
+		strconv.Itoa(r.CountBySeverity(SeverityWarning)),
+		strconv.Itoa(r.CountBySeverity(SeverityInfo)),
+		"",
+	}
+}
+
+func newCSVWriter(w io.Writer, comma rune) *csv.Writer {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	return cw
+}
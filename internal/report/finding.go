@@ -0,0 +1,95 @@
+package report
+
+import (
+	"bullwler/internal/i18n"
+)
+
+// Severity — уровень серьёзности замечания
+type Severity string
+
+// Уровни серьёзности
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Category — раздел аудита, к которому относится замечание
+type Category string
+
+// Разделы аудита
+const (
+	CategoryNetwork     Category = "network"
+	CategorySEO         Category = "seo"
+	CategoryA11y        Category = "a11y"
+	CategorySecurity    Category = "security"
+	CategoryPerformance Category = "performance"
+	CategoryAI          Category = "ai"
+)
+
+// Label — название раздела на текущем языке
+func (c Category) Label() string {
+	return i18n.T("category." + string(c))
+}
+
+// Evidence — фрагмент страницы, подтверждающий замечание
+type Evidence struct {
+	Element   string `json:"element,omitempty"`
+	Attribute string `json:"attribute,omitempty"`
+	Snippet   string `json:"snippet,omitempty"`
+}
+
+// Finding — отдельное замечание аудита, привязанное к правилу
+type Finding struct {
+	RuleID      string    `json:"rule_id"`
+	Category    Category  `json:"category"`
+	Severity    Severity  `json:"severity"`
+	Message     string    `json:"message"`
+	Evidence    *Evidence `json:"evidence,omitempty"`
+	Remediation string    `json:"remediation,omitempty"`
+}
+
+// AddFinding — добавляет замечание по правилу ruleID.
+// Категория, серьёзность и рекомендация по исправлению берутся из каталога правил.
+func (r *SEOReport) AddFinding(ruleID, message string, ev *Evidence) {
+	info, ok := LookupRule(ruleID)
+	if !ok {
+		// Пользовательское правило описано в каталоге реестра, которым проверяется страница
+		if info, ok = lookupRule(r.Rules, ruleID); ok {
+			info = Localize(info)
+		}
+	}
+	if !ok {
+		info = RuleInfo{ID: ruleID, Category: CategorySEO, Severity: SeverityWarning}
+	}
+	r.Findings = append(r.Findings, Finding{
+		RuleID:      ruleID,
+		Category:    info.Category,
+		Severity:    info.Severity,
+		Message:     message,
+		Evidence:    ev,
+		Remediation: info.Remediation,
+	})
+}
+
+// CountBySeverity — считает замечания заданного уровня
+func (r *SEOReport) CountBySeverity(sev Severity) int {
+	n := 0
+	for _, f := range r.Findings {
+		if f.Severity == sev {
+			n++
+		}
+	}
+	return n
+}
+
+// FindingsWhere — возвращает замечания, удовлетворяющие условию
+func (r *SEOReport) FindingsWhere(match func(Finding) bool) []Finding {
+	var out []Finding
+	for _, f := range r.Findings {
+		if match(f) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
@@ -0,0 +1,160 @@
+package report
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"bullwler/internal/i18n"
+)
+
+//go:embed templates/report.html
+var htmlTemplateSource string
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"ruleTitle": ruleTitle,
+	"t":         i18n.T,
+	"lang":      i18n.Lang,
+}).Parse(htmlTemplateSource))
+
+// categoryOrder — разделы HTML-отчёта в порядке вывода
+var categoryOrder = []Category{
+	CategoryNetwork,
+	CategorySEO,
+	CategoryA11y,
+	CategorySecurity,
+	CategoryPerformance,
+	CategoryAI,
+}
+
+type htmlView struct {
+	MainURL    string
+	Generated  string
+	Summary    SiteSummary
+	Info       int
+	AvgAIScore float64
+	AIMaxScore int
+	Categories []htmlCategoryCount
+	TopRules   []RuleCount
+	Pages      []htmlPage
+	Failed     []htmlFailed
+	Baseline   *BaselineDelta
+}
+
+type htmlCategoryCount struct {
+	Label string
+	Count int
+}
+
+type htmlPage struct {
+	Anchor         string
+	URL            string
+	StatusCode     int
+	ResponseTimeMs int64
+	Title          string
+	Description    string
+	Canonical      string
+	H1             int
+	AIScore        int
+	AIMaxScore     int
+	Errors         int
+	Warnings       int
+	Info           int
+	Groups         []htmlGroup
+}
+
+type htmlGroup struct {
+	Label    string
+	Findings []Finding
+}
+
+type htmlFailed struct {
+	URL   string
+	Error string
+}
+
+// WriteHTML — записывает отчёт по странице в виде автономного HTML-файла
+func (r *SEOReport) WriteHTML(w io.Writer) error {
+	return r.asSite().WriteHTML(w)
+}
+
+// WriteHTML — записывает отчёт по сайту в виде автономного HTML-файла:
+// сводка, сортируемая таблица страниц и замечания по разделам
+func (sr *SiteReport) WriteHTML(w io.Writer) error {
+	return htmlTemplate.Execute(w, sr.htmlView())
+}
+
+func (sr *SiteReport) htmlView() htmlView {
+	v := htmlView{
+		MainURL:   sr.MainURL,
+		Generated: time.Now().Format("2006-01-02 15:04"),
+		Summary:   sr.Summary(),
+		Baseline:  sr.Baseline,
+	}
+
+	perCategory := make(map[Category]int)
+	pages := sr.Reports()
+	for i, r := range pages {
+		p := htmlPage{
+			Anchor:         "page-" + strconv.Itoa(i+1),
+			URL:            r.URL,
+			StatusCode:     r.StatusCode,
+			ResponseTimeMs: r.ResponseTimeMs,
+			Title:          r.Title,
+			Description:    r.Description,
+			Canonical:      r.Canonical,
+			H1:             r.HeadingCounts["h1"],
+			AIScore:        r.AIScore,
+			AIMaxScore:     r.AIMaxScore,
+			Errors:         r.CountBySeverity(SeverityError),
+			Warnings:       r.CountBySeverity(SeverityWarning),
+			Info:           r.CountBySeverity(SeverityInfo),
+		}
+		for _, c := range categoryOrder {
+			findings := r.FindingsWhere(func(f Finding) bool { return f.Category == c })
+			if len(findings) == 0 {
+				continue
+			}
+			sort.SliceStable(findings, func(i, j int) bool {
+				return severityRank(findings[i].Severity) < severityRank(findings[j].Severity)
+			})
+			perCategory[c] += len(findings)
+			p.Groups = append(p.Groups, htmlGroup{Label: c.Label(), Findings: findings})
+		}
+		v.Info += p.Info
+		v.AvgAIScore += float64(r.AIScore)
+		if r.AIMaxScore > v.AIMaxScore {
+			v.AIMaxScore = r.AIMaxScore
+		}
+		v.Pages = append(v.Pages, p)
+	}
+	if len(pages) > 0 {
+		v.AvgAIScore /= float64(len(pages))
+	}
+
+	for _, c := range categoryOrder {
+		v.Categories = append(v.Categories, htmlCategoryCount{Label: c.Label(), Count: perCategory[c]})
+	}
+	v.TopRules = v.Summary.TopRules()
+	if len(v.TopRules) > 10 {
+		v.TopRules = v.TopRules[:10]
+	}
+	for _, res := range sr.Failed() {
+		v.Failed = append(v.Failed, htmlFailed{URL: res.URL, Error: res.Error.Error()})
+	}
+	return v
+}
+
+func severityRank(s Severity) int {
+	switch s {
+	case SeverityError:
+		return 0
+	case SeverityWarning:
+		return 1
+	default:
+		return 2
+	}
+}
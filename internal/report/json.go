@@ -0,0 +1,103 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"bullwler/internal/i18n"
+)
+
+type siteReportJSON struct {
+	MainURL    string         `json:"main_url"`
+	MainReport *SEOReport     `json:"main_report"`
+	Summary    SiteSummary    `json:"summary"`
+	SubReports []CrawlResult  `json:"pages"`
+	Baseline   *BaselineDelta `json:"baseline,omitempty"`
+	Stopped    string         `json:"stopped,omitempty"`
+	Sitemap    *SitemapReport `json:"sitemap,omitempty"`
+}
+
+// MarshalJSON — сериализует сводный отчёт вместе с агрегированной сводкой
+func (sr *SiteReport) MarshalJSON() ([]byte, error) {
+	return json.Marshal(siteReportJSON{
+		MainURL:    sr.MainURL,
+		MainReport: sr.MainReport,
+		Summary:    sr.Summary(),
+		SubReports: sr.SubReports,
+		Baseline:   sr.Baseline,
+		Stopped:    sr.Stopped,
+		Sitemap:    sr.Sitemap,
+	})
+}
+
+// WriteJSON — записывает отчёт по странице в формате JSON
+func (r *SEOReport) WriteJSON(w io.Writer) error {
+	return writeJSON(w, r)
+}
+
+// WriteJSON — записывает сводный отчёт по сайту в формате JSON
+func (sr *SiteReport) WriteJSON(w io.Writer) error {
+	return writeJSON(w, sr)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
+
+// ReadJSON — читает сохранённый JSON-отчёт. Отчёт по одной странице
+// оборачивается в SiteReport из одной страницы.
+func ReadJSON(r io.Reader) (*SiteReport, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var probe struct {
+		Pages json.RawMessage `json:"pages"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+
+	if probe.Pages != nil {
+		var sr SiteReport
+		if err := json.Unmarshal(data, &sr); err != nil {
+			return nil, err
+		}
+		for _, res := range sr.SubReports {
+			if res.Report != nil && res.URL == sr.MainURL {
+				sr.MainReport = res.Report
+				break
+			}
+		}
+		return &sr, nil
+	}
+
+	var page SEOReport
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, err
+	}
+	if page.URL == "" {
+		return nil, errors.New(i18n.T("err.report.not-bullwler"))
+	}
+	return page.asSite(), nil
+}
+
+// LoadJSON — читает сохранённый JSON-отчёт из файла
+func LoadJSON(path string) (*SiteReport, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	sr, err := ReadJSON(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return sr, nil
+}
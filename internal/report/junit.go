@@ -0,0 +1,196 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"bullwler/internal/i18n"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Cases      []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// junitRules — правила каталога проверки, которые становятся тестами каждой страницы.
+// Каталог содержит только включённые правила, поэтому отключённые не выдаются за пройденные тесты.
+// Сигналы AI Readiness Score (ai.score.*) не являются проверками и пропускаются.
+func junitRules(catalog []RuleInfo) []RuleInfo {
+	var out []RuleInfo
+	for _, info := range catalog {
+		if strings.HasPrefix(info.ID, "ai.score.") || info.ID == skippedRuleID {
+			continue
+		}
+		out = append(out, info)
+	}
+	return out
+}
+
+// WriteJUnit — записывает отчёт по странице в формате JUnit XML
+func (r *SEOReport) WriteJUnit(w io.Writer) error {
+	return r.asSite().WriteJUnit(w)
+}
+
+// WriteJUnit — записывает отчёт по сайту в формате JUnit XML: каждая страница — набор тестов,
+// каждое включённое правило — тест. Ошибки и предупреждения становятся провалами теста,
+// рекомендации выводятся в system-out, пропущенные страницы — ошибкой теста.
+func (sr *SiteReport) WriteJUnit(w io.Writer) error {
+	catalog := junitRules(sr.catalog())
+	doc := junitTestSuites{Name: toolName}
+
+	for _, res := range sr.results() {
+		var suite junitTestSuite
+		if res.Report == nil {
+			suite = junitSkippedSuite(res)
+		} else {
+			suite = junitPageSuite(res.Report, catalog)
+		}
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		doc.Errors += suite.Errors
+		doc.Suites = append(doc.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func junitSkippedSuite(res CrawlResult) junitTestSuite {
+	msg := i18n.T("junit.not-analyzed")
+	if res.Error != nil {
+		msg = res.Error.Error()
+	}
+	return junitTestSuite{
+		Name:   res.URL,
+		Tests:  1,
+		Errors: 1,
+		Time:   "0",
+		Cases: []junitTestCase{{
+			Name:      skippedRuleID,
+			ClassName: string(CategoryNetwork),
+			Time:      "0",
+			Error:     &junitProblem{Message: msg, Type: string(CategoryNetwork), Text: msg},
+		}},
+	}
+}
+
+func junitPageSuite(r *SEOReport, catalog []RuleInfo) junitTestSuite {
+	byRule := make(map[string][]Finding)
+	for _, f := range r.Findings {
+		byRule[f.RuleID] = append(byRule[f.RuleID], f)
+	}
+
+	suite := junitTestSuite{
+		Name: r.URL,
+		Time: fmt.Sprintf("%.3f", float64(r.ResponseTimeMs)/1000),
+		Properties: []junitProperty{
+			{Name: "status_code", Value: fmt.Sprint(r.StatusCode)},
+			{Name: "ai_score", Value: fmt.Sprintf("%d/%d", r.AIScore, r.AIMaxScore)},
+		},
+	}
+
+	seen := make(map[string]bool)
+	addCase := func(info RuleInfo) {
+		seen[info.ID] = true
+		tc := junitTestCase{Name: info.ID, ClassName: string(info.Category), Time: "0"}
+		if findings := byRule[info.ID]; len(findings) > 0 {
+			text := junitFindingsText(findings)
+			if worst := worstFinding(findings); worst.Severity != SeverityInfo {
+				msg := worst.Message
+				if len(findings) > 1 {
+					msg = i18n.T("junit.total", msg, len(findings))
+				}
+				tc.Failure = &junitProblem{Message: msg, Type: string(worst.Severity), Text: text}
+				suite.Failures++
+			} else {
+				tc.SystemOut = text
+			}
+		}
+		suite.Tests++
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	for _, info := range catalog {
+		addCase(info)
+	}
+	// Замечания правил вне каталога (например, зарегистрированных без метаданных)
+	for _, f := range r.Findings {
+		if !seen[f.RuleID] && !strings.HasPrefix(f.RuleID, "ai.score.") {
+			addCase(RuleInfo{ID: f.RuleID, Category: f.Category, Severity: f.Severity})
+		}
+	}
+	return suite
+}
+
+// worstFinding — самое серьёзное замечание из списка
+func worstFinding(findings []Finding) Finding {
+	worst := findings[0]
+	for _, f := range findings[1:] {
+		if severityRank(f.Severity) < severityRank(worst.Severity) {
+			worst = f
+		}
+	}
+	return worst
+}
+
+func junitFindingsText(findings []Finding) string {
+	var b strings.Builder
+	for _, f := range findings {
+		fmt.Fprintf(&b, "[%s] %s\n", f.Severity, f.Message)
+		if ev := f.Evidence; ev != nil {
+			if ev.Snippet != "" {
+				fmt.Fprintf(&b, "  %s\n", ev.Snippet)
+			} else if ev.Element != "" || ev.Attribute != "" {
+				fmt.Fprintf(&b, "  %s %s\n", ev.Element, ev.Attribute)
+			}
+		}
+		if f.Remediation != "" {
+			fmt.Fprintf(&b, "  → %s\n", f.Remediation)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,28 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestJUnitOmitsDisabledRules(t *testing.T) {
+	r := New("https://example.com/", nil)
+	for _, info := range Rules() {
+		if info.ID != "seo.title.missing" {
+			r.Rules = append(r.Rules, info)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteJUnit(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Contains(out, `name="seo.title.missing"`) {
+		t.Error("disabled rule is reported as a test case")
+	}
+	if !strings.Contains(out, `name="seo.description.missing"`) {
+		t.Error("enabled rule is missing from the test cases")
+	}
+}
@@ -0,0 +1,188 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"bullwler/internal/i18n"
+)
+
+// markdownNewLimit — сколько новых замечаний перечислять в блоке регрессий
+const markdownNewLimit = 50
+
+// WriteMarkdown — записывает отчёт по странице в формате GitHub-flavored Markdown
+func (r *SEOReport) WriteMarkdown(w io.Writer) error {
+	return r.asSite().WriteMarkdown(w)
+}
+
+// WriteMarkdown — записывает компактный отчёт по сайту в формате GitHub-flavored Markdown,
+// подходящем для комментария к pull request
+func (sr *SiteReport) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	sum := sr.Summary()
+	pages := sr.Reports()
+
+	info, aiTotal, aiMax := 0, 0, 0
+	for _, r := range pages {
+		info += r.CountBySeverity(SeverityInfo)
+		aiTotal += r.AIScore
+		if r.AIMaxScore > aiMax {
+			aiMax = r.AIMaxScore
+		}
+	}
+	avgAI := 0.0
+	if len(pages) > 0 {
+		avgAI = float64(aiTotal) / float64(len(pages))
+	}
+
+	fmt.Fprintf(&b, "## 🐂 Bullwler: %s\n\n", sr.MainURL)
+	if sr.Stopped != "" {
+		fmt.Fprintf(&b, "> ⚠️ %s\n\n", i18n.T("print.site.stopped."+sr.Stopped))
+	}
+	fmt.Fprintf(&b, "| %s | %s |\n|---|---:|\n", i18n.T("md.metric"), i18n.T("md.value"))
+	fmt.Fprintf(&b, "| %s | %d |\n", i18n.T("md.pages"), sum.Pages)
+	fmt.Fprintf(&b, "| ❌ %s | %d |\n", i18n.T("md.errors"), sum.Errors)
+	fmt.Fprintf(&b, "| ⚠️ %s | %d |\n", i18n.T("md.warnings"), sum.Warnings)
+	fmt.Fprintf(&b, "| ℹ️ %s | %d |\n", i18n.T("md.info"), info)
+	fmt.Fprintf(&b, "| %s | %d |\n", i18n.T("md.broken"), sum.BrokenPages)
+	fmt.Fprintf(&b, "| %s | %.1f / %d |\n", i18n.T("md.avg-ai"), avgAI, aiMax)
+
+	if d := sr.Baseline; d != nil {
+		writeMarkdownBaseline(&b, d, pages)
+	}
+
+	if top := sum.TopRules(); len(top) > 0 {
+		fmt.Fprintf(&b, "\n### 📉 %s\n\n| %s | %s | %s |\n|---|---:|---:|\n",
+			i18n.T("md.top-rules"), i18n.T("md.rule"), i18n.T("md.pages"), i18n.T("md.total"))
+		for i, rc := range top {
+			if i == 5 {
+				break
+			}
+			fmt.Fprintf(&b, "| %s `%s` | %d | %d |\n", mdCell(ruleTitle(rc.RuleID)), rc.RuleID, rc.Pages, rc.Count)
+		}
+	}
+
+	if sm := sr.Sitemap; sm != nil && (len(sm.Issues) > 0 || len(sm.Orphans) > 0 || len(sm.Unlisted) > 0) {
+		fmt.Fprintf(&b, "\n### 🗺️ %s\n\n", i18n.T("md.sitemap"))
+		fmt.Fprintf(&b, "%s\n\n", i18n.T("print.sitemap.urls", sm.URLs, len(sm.Files)))
+		if len(sm.Issues) > 0 {
+			fmt.Fprintf(&b, "| URL | %s |\n|---|---|\n", i18n.T("md.reason"))
+			for _, is := range sm.Issues {
+				fmt.Fprintf(&b, "| %s | %s |\n", mdCell(is.URL), mdCell(sitemapProblem(is)))
+			}
+			b.WriteString("\n")
+		}
+		for _, part := range []struct {
+			title string
+			urls  []string
+		}{{i18n.T("print.sitemap.orphans"), sm.Orphans}, {i18n.T("print.sitemap.unlisted"), sm.Unlisted}} {
+			if len(part.urls) == 0 {
+				continue
+			}
+			fmt.Fprintf(&b, "<details>\n<summary>%s: %d</summary>\n\n", part.title, len(part.urls))
+			for _, u := range part.urls {
+				fmt.Fprintf(&b, "- %s\n", mdText(u))
+			}
+			b.WriteString("\n</details>\n")
+		}
+		if sm.Partial && len(sm.Orphans) > 0 {
+			fmt.Fprintf(&b, "\n> %s\n", i18n.T("print.sitemap.partial"))
+		}
+	}
+
+	if failed := sr.Failed(); len(failed) > 0 {
+		fmt.Fprintf(&b, "\n### 🚫 %s\n\n| URL | %s |\n|---|---|\n", i18n.T("md.skipped"), i18n.T("md.reason"))
+		for _, res := range failed {
+			fmt.Fprintf(&b, "| %s | %s |\n", mdCell(res.URL), mdCell(res.Error.Error()))
+		}
+	}
+
+	fmt.Fprintf(&b, "\n### 📄 %s\n\n| URL | %s | ❌ | ⚠️ | ℹ️ | AI |\n|---|---:|---:|---:|---:|---:|\n", i18n.T("md.pages-section"), i18n.T("md.status"))
+	for _, r := range pages {
+		fmt.Fprintf(&b, "| %s | %d | %d | %d | %d | %d |\n", mdCell(r.URL), r.StatusCode,
+			r.CountBySeverity(SeverityError), r.CountBySeverity(SeverityWarning), r.CountBySeverity(SeverityInfo), r.AIScore)
+	}
+
+	for _, r := range pages {
+		if len(r.Findings) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n<details>\n<summary>%s — %s</summary>\n\n", htmlEscaper.Replace(r.URL), i18n.T("md.page-findings", len(r.Findings)))
+		for _, c := range categoryOrder {
+			findings := r.FindingsWhere(func(f Finding) bool { return f.Category == c })
+			if len(findings) == 0 {
+				continue
+			}
+			fmt.Fprintf(&b, "**%s**\n\n", c.Label())
+			for _, f := range findings {
+				fmt.Fprintf(&b, "- %s %s `%s`\n", severityIcon(f.Severity), mdText(f.Message), f.RuleID)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("</details>\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMarkdownBaseline(b *strings.Builder, d *BaselineDelta, pages []*SEOReport) {
+	if d.New == 0 {
+		fmt.Fprintf(b, "\n### ✅ %s\n", i18n.T("md.baseline.no-new"))
+	} else {
+		fmt.Fprintf(b, "\n### 🚨 %s\n\n", i18n.T("md.baseline.new", d.New))
+		n := 0
+		for _, r := range pages {
+			for _, f := range r.Findings {
+				if n == markdownNewLimit {
+					break
+				}
+				fmt.Fprintf(b, "> - %s **%s** `%s` — %s\n", severityIcon(f.Severity), mdText(f.Message), f.RuleID, mdText(r.URL))
+				n++
+			}
+		}
+		if d.New > n {
+			fmt.Fprintf(b, "> - …%s\n", i18n.T("gate.and-more", d.New-n))
+		}
+	}
+	fmt.Fprintf(b, "\n%s\n", i18n.T("md.baseline.summary", d.File, d.Suppressed, len(d.Fixed)))
+
+	if len(d.Fixed) > 0 {
+		fmt.Fprintf(b, "\n<details>\n<summary>✅ %s</summary>\n\n", i18n.T("md.baseline.fixed"))
+		for _, f := range d.Fixed {
+			fmt.Fprintf(b, "- %s `%s` — %s\n", mdText(ruleTitle(f.RuleID)), f.RuleID, mdText(f.URL))
+		}
+		b.WriteString("\n</details>\n")
+	}
+}
+
+func ruleTitle(id string) string {
+	if info, ok := LookupRule(id); ok && info.Title != "" {
+		return info.Title
+	}
+	return id
+}
+
+func severityIcon(s Severity) string {
+	switch s {
+	case SeverityError:
+		return "❌"
+	case SeverityWarning:
+		return "⚠️"
+	default:
+		return "ℹ️"
+	}
+}
+
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// mdText — экранирует текст, чтобы теги и разметка из страницы не ломали комментарий
+func mdText(s string) string {
+	return htmlEscaper.Replace(strings.ReplaceAll(s, "\n", " "))
+}
+
+// mdCell — экранирует текст для ячейки таблицы
+func mdCell(s string) string {
+	return strings.ReplaceAll(mdText(s), "|", "\\|")
+}
@@ -2,114 +2,120 @@ package report
 
 import "strings"
 
-// MaxAIScore - максимальное значение AI Score
-const MaxAIScore = 14
-
 // SEOReport - структура отчета по странице
 type SEOReport struct {
-	URL string
+	URL string `json:"url"`
 
 	// Производительность
-	ResponseTimeMs int64
-	StatusCode     int
-	IsHTTPS        bool
-	Redirects      []string
-	HasRobotsTxt   bool
-	HasSitemap     bool
+	ResponseTimeMs int64    `json:"response_time_ms"`
+	StatusCode     int      `json:"status_code"`
+	IsHTTPS        bool     `json:"is_https"`
+	Redirects      []string `json:"redirects"`
+	LastModified   string   `json:"last_modified,omitempty"`
+	HasRobotsTxt   bool     `json:"has_robots_txt"`
+	HasSitemap     bool     `json:"has_sitemap"`
 
 	// Мета
-	Title             string
-	TitleLength       int
-	Description       string
-	DescriptionLength int
-	HasViewport       bool
-	HasCanonical      bool
+	Title             string `json:"title"`
+	TitleLength       int    `json:"title_length"`
+	Description       string `json:"description"`
+	DescriptionLength int    `json:"description_length"`
+	HasViewport       bool   `json:"has_viewport"`
+	HasCanonical      bool   `json:"has_canonical"`
+	Canonical         string `json:"canonical"`
+	MetaRobots        string `json:"meta_robots,omitempty"`
+	XRobotsTag        string `json:"x_robots_tag,omitempty"`
+	Noindex           bool   `json:"noindex"`
 
 	// Open Graph / Twitter
-	OG      map[string]string
-	Twitter map[string]string
+	OG      map[string]string `json:"open_graph"`
+	Twitter map[string]string `json:"twitter"`
 
 	// Структурированные данные
-	JSONLD                []map[string]interface{}
-	MicrodataTypes        []string
-	RDFaVocabularies      []string
-	HasJSONLD             bool
-	HasMicrodata          bool
-	HasRDFa               bool
-	SchemaOrgValidationOK bool
-	SchemaOrgErrors       []string
-	SchemaTypes           map[string]bool
+	JSONLD                []map[string]interface{} `json:"json_ld"`
+	MicrodataTypes        []string                 `json:"microdata_types"`
+	RDFaVocabularies      []string                 `json:"rdfa_vocabularies"`
+	HasJSONLD             bool                     `json:"has_json_ld"`
+	HasMicrodata          bool                     `json:"has_microdata"`
+	HasRDFa               bool                     `json:"has_rdfa"`
+	SchemaOrgValidationOK bool                     `json:"schema_org_valid"`
+	SchemaOrgErrors       []string                 `json:"schema_org_errors"`
+	SchemaTypes           map[string]bool          `json:"-"`
 
 	// Семантика
-	HasHeader  bool
-	HasNav     bool
-	HasMain    bool
-	HasArticle bool
-	HasSection bool
-	HasFooter  bool
-	Paragraphs []string
-	AllIDs     []string
+	HasHeader  bool     `json:"has_header"`
+	HasNav     bool     `json:"has_nav"`
+	HasMain    bool     `json:"has_main"`
+	HasArticle bool     `json:"has_article"`
+	HasSection bool     `json:"has_section"`
+	HasFooter  bool     `json:"has_footer"`
+	Paragraphs []string `json:"-"`
+	AllIDs     []string `json:"-"`
 
 	// Заголовки
-	HeadingCounts    map[string]int
-	HeadingTexts     map[string][]string
-	HeadingsSequence []string
-	HeadingsValid    bool
+	HeadingCounts    map[string]int      `json:"heading_counts"`
+	HeadingTexts     map[string][]string `json:"heading_texts"`
+	HeadingsSequence []string            `json:"headings_sequence"`
+	HeadingsValid    bool                `json:"headings_valid"`
 
 	// Доступность
-	ImageCount        int
-	ImageWithoutAlt   int
-	ImageWithEmptyAlt int
-	AriaLabels        int
-	AriaLabelledBy    int
-	Roles             int
-	InvalidButtons    int
-	InvalidLinks      int
-	A11yErrors        []string
-	A11yWarnings      []string
+	ImageCount        int `json:"image_count"`
+	ImageWithoutAlt   int `json:"images_without_alt"`
+	ImageWithEmptyAlt int `json:"images_with_empty_alt"`
+	AriaLabels        int `json:"aria_labels"`
+	AriaLabelledBy    int `json:"aria_labelledby"`
+	Roles             int `json:"roles"`
+	InvalidButtons    int `json:"buttons_without_type"`
+	InvalidLinks      int `json:"links_without_href"`
 
 	// Формы
-	FormCount            int
-	InputWithoutLabel    int
-	InputWithoutName     int
-	RequiredWithoutLabel int
-	LabelsWithoutFor     int
+	FormCount            int `json:"form_count"`
+	InputWithoutLabel    int `json:"inputs_without_label"`
+	InputWithoutName     int `json:"inputs_without_name"`
+	RequiredWithoutLabel int `json:"required_without_label"`
+	LabelsWithoutFor     int `json:"labels_without_for"`
 
 	// Безопасность
-	InsecureExternalLinks  int
-	InsecureResources      int
-	MissingSecurityHeaders []string
-	FormsWithGetMethod     int
-	InsecureFormActions    int
+	InsecureExternalLinks  int      `json:"insecure_external_links"`
+	InsecureResources      int      `json:"insecure_resources"`
+	MissingSecurityHeaders []string `json:"missing_security_headers"`
+	FormsWithGetMethod     int      `json:"forms_with_get_method"`
+	InsecureFormActions    int      `json:"insecure_form_actions"`
 
 	// AI-дружелюбность
-	TextBytes          int
-	HTMLBytes          int
-	TextToHTMLRatio    float64
-	HasDatePublished   bool
-	ParagraphCount     int
-	AvgParagraphLength int
-	AIScore            int
-	HasDateModified    bool
-	HasAuthor          bool
-	HasAuthorWithName  bool
-	ListCount          int
-	TableCount         int
-	HTMLLang           string
-	CanonicalHost      string
-	Host               string
-	HasDirectAnswer    bool
-	TextDensityScore   float64
-	HasFAQStructured   bool
-	HasHowToStructured bool
+	TextBytes          int     `json:"text_bytes"`
+	HTMLBytes          int     `json:"html_bytes"`
+	TextToHTMLRatio    float64 `json:"text_to_html_ratio"`
+	HasDatePublished   bool    `json:"has_date_published"`
+	ParagraphCount     int     `json:"paragraph_count"`
+	AvgParagraphLength int     `json:"avg_paragraph_length"`
+	AIScore            int     `json:"ai_score"`
+	AIMaxScore         int     `json:"ai_max_score"`
+	HasDateModified    bool    `json:"has_date_modified"`
+	HasAuthor          bool    `json:"has_author"`
+	HasAuthorWithName  bool    `json:"has_author_with_name"`
+	ListCount          int     `json:"list_count"`
+	TableCount         int     `json:"table_count"`
+	HTMLLang           string  `json:"html_lang"`
+	CanonicalHost      string  `json:"canonical_host"`
+	Host               string  `json:"host"`
+	HasDirectAnswer    bool    `json:"has_direct_answer"`
+	TextDensityScore   float64 `json:"text_density_score"`
+	HasFAQStructured   bool    `json:"has_faq_structured"`
+	HasHowToStructured bool    `json:"has_howto_structured"`
 
 	// Для краулера
-	AllLinks []string
+	AllLinks []string `json:"links"`
+
+	// Замечания
+	Findings []Finding `json:"findings"`
+	// Rules — описания правил, включённых при проверке страницы, с пользовательскими правилами реестра;
+	// по ним строятся SARIF и JUnit. Срез общий для всех отчётов одного реестра и не изменяется.
+	// nil — встроенный каталог.
+	Rules []RuleInfo `json:"-"`
 
-	// Сообщения
-	Errors   []string
-	Warnings []string
-	Info     []string
+	// Baseline — сравнение с базовой линией при аудите одной страницы
+	Baseline *BaselineDelta `json:"baseline,omitempty"`
 }
 
 // New - возвращает новый отчет
@@ -122,9 +128,7 @@ func New(rawURL string, schemaTypes map[string]bool) *SEOReport {
 		JSONLD:                 []map[string]interface{}{},
 		HeadingCounts:          make(map[string]int),
 		HeadingTexts:           make(map[string][]string),
-		Errors:                 []string{},
-		Warnings:               []string{},
-		Info:                   []string{},
+		Findings:               []Finding{},
 		SchemaTypes:            schemaTypes,
 		MissingSecurityHeaders: []string{},
 		AllLinks:               []string{},
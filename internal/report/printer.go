@@ -2,109 +2,118 @@ package report
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"sort"
 	"strconv"
 	"strings"
 
+	"bullwler/internal/i18n"
+
 	"github.com/fatih/color"
 )
 
 // Print для SEOReport (одна страница)
 func (r *SEOReport) Print() {
+	r.Fprint(os.Stdout)
+}
+
+// Fprint — выводит отчёт по странице в w
+func (r *SEOReport) Fprint(w io.Writer) {
 	green := color.New(color.FgGreen).SprintFunc()
 	yellow := color.New(color.FgYellow).SprintFunc()
 	red := color.New(color.FgRed).SprintFunc()
 	cyan := color.New(color.FgCyan).SprintFunc()
 	white := color.New(color.FgWhite).SprintFunc()
 
-	fmt.Println(cyan("\n🔍 РЕЗУЛЬТАТ АУДИТА"), r.URL)
-	fmt.Println(strings.Repeat("─", 65))
+	fmt.Fprintln(w, cyan("\n🔍 "+i18n.T("print.page.header")), r.URL)
+	fmt.Fprintln(w, strings.Repeat("─", 65))
 
-	fmt.Printf("🌐 URL: %s\n", white(r.URL))
-	fmt.Printf("⏱️  Загрузка: %s мс", white(r.ResponseTimeMs))
+	fmt.Fprintf(w, "🌐 URL: %s\n", white(r.URL))
+	fmt.Fprintf(w, "⏱️  "+i18n.T("print.page.load-time"), white(r.ResponseTimeMs))
 	if r.ResponseTimeMs > 3000 {
-		fmt.Print(" " + red("(!)"))
+		fmt.Fprint(w, " "+red("(!)"))
 	}
-	fmt.Println()
-	fmt.Printf("🔒 HTTPS: %s\n", boolIcon(r.IsHTTPS))
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "🔒 HTTPS: %s\n", boolIcon(r.IsHTTPS))
 
-	fmt.Println("\n" + cyan("🤖 ИИ-ГОТОВНОСТЬ (AI Readiness)"))
-	fmt.Printf("  Соотношение текста: %.1f%%", r.TextToHTMLRatio*100)
+	fmt.Fprintln(w, "\n"+cyan("🤖 "+i18n.T("print.section.ai")))
+	fmt.Fprintf(w, "  "+i18n.T("print.ai.text-ratio"), r.TextToHTMLRatio*100)
 	if r.TextToHTMLRatio < 0.05 {
-		fmt.Print(" " + red("(!)"))
+		fmt.Fprint(w, " "+red("(!)"))
 	}
-	fmt.Println()
-	fmt.Printf("  Основной контент в <main>: %s\n", boolIcon(r.HasMain))
-	fmt.Printf("  Дата публикации: %s\n", boolIcon(r.HasDatePublished))
-	fmt.Printf("  Структурированные данные: %s\n", boolIcon(r.SchemaOrgValidationOK))
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "  %s: %s\n", i18n.T("print.ai.main"), boolIcon(r.HasMain))
+	fmt.Fprintf(w, "  %s: %s\n", i18n.T("print.ai.date-published"), boolIcon(r.HasDatePublished))
+	fmt.Fprintf(w, "  %s: %s\n", i18n.T("print.ai.structured-data"), boolIcon(r.SchemaOrgValidationOK))
 
-	fmt.Printf("  AI Readiness Score: %s/%d\n", white(strconv.Itoa(r.AIScore)), MaxAIScore)
+	fmt.Fprintf(w, "  AI Readiness Score: %s/%d\n", white(strconv.Itoa(r.AIScore)), r.AIMaxScore)
 
-	fmt.Println("\n" + cyan("📄 SEO"))
-	fmt.Printf("  Title: %s %s\n", white(strconvEllipsis(r.Title, 50)), grayf("(%d)", r.TitleLength))
-	fmt.Printf("  Desc:  %s %s\n", white(strconvEllipsis(r.Description, 50)), grayf("(%d)", r.DescriptionLength))
-	fmt.Printf("  Viewport: %s | Canonical: %s\n", boolIcon(r.HasViewport), boolIcon(r.HasCanonical))
+	fmt.Fprintln(w, "\n"+cyan("📄 SEO"))
+	fmt.Fprintf(w, "  Title: %s %s\n", white(strconvEllipsis(r.Title, 50)), grayf("(%d)", r.TitleLength))
+	fmt.Fprintf(w, "  Desc:  %s %s\n", white(strconvEllipsis(r.Description, 50)), grayf("(%d)", r.DescriptionLength))
+	fmt.Fprintf(w, "  Viewport: %s | Canonical: %s\n", boolIcon(r.HasViewport), boolIcon(r.HasCanonical))
 
 	if len(r.OG) > 0 {
-		fmt.Println("\n" + cyan("🖼️  OPEN GRAPH"))
+		fmt.Fprintln(w, "\n"+cyan("🖼️  OPEN GRAPH"))
 		for _, k := range []string{"title", "description", "image", "url", "type"} {
 			if v, ok := r.OG[k]; ok && v != "" {
-				fmt.Printf("  og:%-12s: %s\n", k, white(strconvEllipsis(v, 40)))
+				fmt.Fprintf(w, "  og:%-12s: %s\n", k, white(strconvEllipsis(v, 40)))
 			}
 		}
 	}
 
 	if len(r.Twitter) > 0 {
-		fmt.Println("\n" + cyan("🐦 TWITTER CARDS"))
+		fmt.Fprintln(w, "\n"+cyan("🐦 TWITTER CARDS"))
 		for _, k := range []string{"card", "title", "description", "image"} {
 			if v, ok := r.Twitter[k]; ok && v != "" {
-				fmt.Printf("  twitter:%-8s: %s\n", k, white(strconvEllipsis(v, 40)))
+				fmt.Fprintf(w, "  twitter:%-8s: %s\n", k, white(strconvEllipsis(v, 40)))
 			}
 		}
 	}
 
-	fmt.Println("\n" + cyan("🧩 СТРУКТУРИРОВАННЫЕ ДАННЫЕ"))
+	fmt.Fprintln(w, "\n"+cyan("🧩 "+i18n.T("print.section.structured-data")))
 	if r.HasJSONLD {
-		fmt.Printf("  JSON-LD: %s", boolIcon(r.SchemaOrgValidationOK))
+		fmt.Fprintf(w, "  JSON-LD: %s", boolIcon(r.SchemaOrgValidationOK))
 		if len(r.JSONLD) > 0 {
 			types := []string{}
 			for _, ld := range r.JSONLD {
 				types = append(types, extractTypes(ld["@type"])...)
 			}
 			if len(types) > 0 {
-				fmt.Printf(" → %s", white(strings.Join(types, ", ")))
+				fmt.Fprintf(w, " → %s", white(strings.Join(types, ", ")))
 			}
 		}
 		if !r.SchemaOrgValidationOK && len(r.SchemaOrgErrors) > 0 {
-			fmt.Printf("%s", " "+red("(!)"))
+			fmt.Fprintf(w, "%s", " "+red("(!)"))
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 	if r.HasMicrodata {
-		fmt.Printf("  Micro %s", green("найден"))
+		fmt.Fprintf(w, "  Microdata: %s", green(i18n.T("print.found")))
 		if len(r.MicrodataTypes) > 0 {
-			fmt.Printf(" → %s", white(strings.Join(r.MicrodataTypes, ", ")))
+			fmt.Fprintf(w, " → %s", white(strings.Join(r.MicrodataTypes, ", ")))
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 	if r.HasRDFa {
-		fmt.Printf("  RDFa: %s", green("найден"))
+		fmt.Fprintf(w, "  RDFa: %s", green(i18n.T("print.found")))
 		if len(r.RDFaVocabularies) > 0 {
-			fmt.Printf(" → vocab=%s", white(r.RDFaVocabularies[0]))
+			fmt.Fprintf(w, " → vocab=%s", white(r.RDFaVocabularies[0]))
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 	if !r.HasJSONLD && !r.HasMicrodata && !r.HasRDFa {
-		fmt.Printf("  Структурированные данные: %s\n", red("отсутствуют"))
+		fmt.Fprintf(w, "  %s: %s\n", i18n.T("print.ai.structured-data"), red(i18n.T("print.missing")))
 	}
 
-	fmt.Println("\n" + cyan("🧱 СЕМАНТИЧЕСКАЯ РАЗМЕТКА"))
-	fmt.Printf("  <header>: %s, <nav>: %s, <main>: %s\n",
+	fmt.Fprintln(w, "\n"+cyan("🧱 "+i18n.T("print.section.semantics")))
+	fmt.Fprintf(w, "  <header>: %s, <nav>: %s, <main>: %s\n",
 		boolIcon(r.HasHeader), boolIcon(r.HasNav), boolIcon(r.HasMain))
-	fmt.Printf("  <article>: %s, <section>: %s, <footer>: %s\n",
+	fmt.Fprintf(w, "  <article>: %s, <section>: %s, <footer>: %s\n",
 		boolIcon(r.HasArticle), boolIcon(r.HasSection), boolIcon(r.HasFooter))
 
-	fmt.Println("\n" + cyan("📑 ЗАГОЛОВКИ"))
+	fmt.Fprintln(w, "\n"+cyan("📑 "+i18n.T("print.section.headings")))
 	counts := []string{}
 	for _, level := range []string{"h1", "h2", "h3", "h4", "h5", "h6"} {
 		if cnt := r.HeadingCounts[level]; cnt > 0 {
@@ -112,105 +121,124 @@ func (r *SEOReport) Print() {
 		}
 	}
 	if len(counts) > 0 {
-		fmt.Printf("  %s\n", strings.Join(counts, ", "))
+		fmt.Fprintf(w, "  %s\n", strings.Join(counts, ", "))
 	} else {
-		fmt.Println("  Нет заголовков h1–h6")
+		fmt.Fprintln(w, "  "+i18n.T("print.headings.none"))
 	}
-	fmt.Printf("  Иерархия: %s\n", boolIcon(r.HeadingsValid))
+	fmt.Fprintf(w, "  %s: %s\n", i18n.T("print.headings.hierarchy"), boolIcon(r.HeadingsValid))
 
 	for _, level := range []string{"h1", "h2", "h3", "h4", "h5", "h6"} {
 		if texts, exists := r.HeadingTexts[level]; exists && len(texts) > 0 {
-			fmt.Printf("    %s: ", level)
+			fmt.Fprintf(w, "    %s: ", level)
 			for i, text := range texts {
 				if i >= 3 {
-					fmt.Print(grayf("(+%d)", len(texts)-3))
+					fmt.Fprint(w, grayf("(+%d)", len(texts)-3))
 					break
 				}
 				if i > 0 {
-					fmt.Print("; ")
+					fmt.Fprint(w, "; ")
 				}
-				fmt.Print(white(strconvEllipsis(text, 30)))
+				fmt.Fprint(w, white(strconvEllipsis(text, 30)))
 			}
-			fmt.Println()
+			fmt.Fprintln(w)
 		}
 	}
 
-	fmt.Println("\n" + cyan("♿ ДОСТУПНОСТЬ (a11y)"))
-	fmt.Printf("  Изображений: %s | Без alt: %s | alt=\"\": %s\n",
+	fmt.Fprintln(w, "\n"+cyan("♿ "+i18n.T("print.section.a11y")))
+	fmt.Fprintf(w, "  "+i18n.T("print.a11y.images")+"\n",
 		white(strconv.Itoa(r.ImageCount)), warnCount(r.ImageWithoutAlt), warnCount(r.ImageWithEmptyAlt))
-	fmt.Printf("  ARIA: label=%s, labelledby=%s, role=%s\n",
+	fmt.Fprintf(w, "  ARIA: label=%s, labelledby=%s, role=%s\n",
 		white(strconv.Itoa(r.AriaLabels)), white(strconv.Itoa(r.AriaLabelledBy)), white(strconv.Itoa(r.Roles)))
-	fmt.Printf("  Кнопок без type: %s | Ссылок без href: %s\n",
+	fmt.Fprintf(w, "  "+i18n.T("print.a11y.buttons-links")+"\n",
 		warnCount(r.InvalidButtons), warnCount(r.InvalidLinks))
 
-	if len(r.A11yErrors) > 0 {
-		fmt.Printf("  ❌ Критические a11y-ошибки:\n")
-		for _, e := range r.A11yErrors {
-			fmt.Printf("    • %s\n", e)
-		}
+	a11yErrors := r.FindingsWhere(func(f Finding) bool {
+		return f.Category == CategoryA11y && f.Severity == SeverityError
+	})
+	a11yWarnings := r.FindingsWhere(func(f Finding) bool {
+		return f.Category == CategoryA11y && f.Severity != SeverityError
+	})
+	if len(a11yErrors) > 0 {
+		fmt.Fprintf(w, "  ❌ %s:\n", i18n.T("print.a11y.errors"))
+		printFindings(w, "    ", a11yErrors)
 	}
-	if len(r.A11yWarnings) > 0 {
-		fmt.Printf("  ⚠️  a11y-предупреждения:\n")
-		for _, w := range r.A11yWarnings {
-			fmt.Printf("    • %s\n", w)
-		}
+	if len(a11yWarnings) > 0 {
+		fmt.Fprintf(w, "  ⚠️  %s:\n", i18n.T("print.a11y.warnings"))
+		printFindings(w, "    ", a11yWarnings)
 	}
 	if r.FormCount > 0 {
-		fmt.Println("\n" + cyan("📋 ФОРМЫ"))
-		fmt.Printf("  Форм: %s\n", white(strconv.Itoa(r.FormCount)))
-		fmt.Printf("  Полей без <label>: %s\n", warnCount(r.InputWithoutLabel))
-		fmt.Printf("  Полей без name: %s\n", warnCount(r.InputWithoutName))
-		fmt.Printf("  Обязательных без описания: %s\n", warnCount(r.RequiredWithoutLabel))
+		fmt.Fprintln(w, "\n"+cyan("📋 "+i18n.T("print.section.forms")))
+		fmt.Fprintf(w, "  %s: %s\n", i18n.T("print.forms.count"), white(strconv.Itoa(r.FormCount)))
+		fmt.Fprintf(w, "  %s: %s\n", i18n.T("print.forms.without-label"), warnCount(r.InputWithoutLabel))
+		fmt.Fprintf(w, "  %s: %s\n", i18n.T("print.forms.without-name"), warnCount(r.InputWithoutName))
+		fmt.Fprintf(w, "  %s: %s\n", i18n.T("print.forms.required-without-label"), warnCount(r.RequiredWithoutLabel))
 	}
 
 	if r.InsecureExternalLinks > 0 || r.InsecureResources > 0 || len(r.MissingSecurityHeaders) > 0 {
-		fmt.Println("\n" + cyan("🔐 БЕЗОПАСНОСТЬ"))
+		fmt.Fprintln(w, "\n"+cyan("🔐 "+i18n.T("print.section.security")))
 		if r.InsecureExternalLinks > 0 {
-			fmt.Printf("  Ссылок без noopener/noreferrer: %s\n", warnCount(r.InsecureExternalLinks))
+			fmt.Fprintf(w, "  %s: %s\n", i18n.T("print.security.insecure-links"), warnCount(r.InsecureExternalLinks))
 		}
 		if r.InsecureResources > 0 {
-			fmt.Printf("  Небезопасных ресурсов (HTTP): %s\n", warnCount(r.InsecureResources))
+			fmt.Fprintf(w, "  %s: %s\n", i18n.T("print.security.insecure-resources"), warnCount(r.InsecureResources))
 		}
 		if len(r.MissingSecurityHeaders) > 0 {
-			fmt.Printf("  Отсутствующие заголовки: %s\n", white(strings.Join(r.MissingSecurityHeaders, ", ")))
+			fmt.Fprintf(w, "  %s: %s\n", i18n.T("print.security.missing-headers"), white(strings.Join(r.MissingSecurityHeaders, ", ")))
 		}
 		if r.FormsWithGetMethod > 0 {
-			fmt.Printf("  Форм с method=\"get\": %s\n", warnCount(r.FormsWithGetMethod))
+			fmt.Fprintf(w, "  %s: %s\n", i18n.T("print.security.get-forms"), warnCount(r.FormsWithGetMethod))
 		}
 	}
 
-	if len(r.Warnings) > 0 {
-		fmt.Println("\n" + red("⚠️  ПРОБЛЕМЫ (требуют исправления):"))
-		for _, w := range r.Warnings {
-			fmt.Printf("  • %s\n", w)
-		}
+	warnings := r.FindingsWhere(func(f Finding) bool {
+		return f.Category != CategoryA11y && f.Severity == SeverityWarning
+	})
+	infos := r.FindingsWhere(func(f Finding) bool {
+		return f.Category != CategoryA11y && f.Severity == SeverityInfo
+	})
+	errs := r.FindingsWhere(func(f Finding) bool {
+		return f.Category != CategoryA11y && f.Severity == SeverityError
+	})
+
+	if len(warnings) > 0 {
+		fmt.Fprintln(w, "\n"+red("⚠️  "+i18n.T("print.findings.warnings")+":"))
+		printFindings(w, "  ", warnings)
 	}
 
-	if len(r.Info) > 0 {
-		fmt.Println("\n" + yellow("ℹ️  ЗАМЕЧАНИЯ:"))
-		for _, i := range r.Info {
-			fmt.Printf("  • %s\n", i)
-		}
+	if len(infos) > 0 {
+		fmt.Fprintln(w, "\n"+yellow("ℹ️  "+i18n.T("print.findings.info")+":"))
+		printFindings(w, "  ", infos)
 	}
 
-	if len(r.Errors) > 0 {
-		fmt.Println("\n" + red("❌ КРИТИЧЕСКИЕ ОШИБКИ:"))
-		for _, e := range r.Errors {
-			fmt.Printf("  • %s\n", e)
-		}
-	} else if len(r.Warnings) == 0 {
-		fmt.Println("\n" + green("✅ ВСЁ В ПОРЯДКЕ!"))
+	if len(errs) > 0 {
+		fmt.Fprintln(w, "\n"+red("❌ "+i18n.T("print.findings.errors")+":"))
+		printFindings(w, "  ", errs)
+	} else if len(warnings) == 0 {
+		fmt.Fprintln(w, "\n"+green("✅ "+i18n.T("print.findings.none")))
 	}
 
-	fmt.Println("\n" + strings.Repeat("─", 65))
+	printBaseline(w, r.Baseline)
+
+	fmt.Fprintln(w, "\n"+strings.Repeat("─", 65))
 }
 
 // Print для SiteReport (сайт целиком)
 func (sr *SiteReport) Print() {
+	sr.Fprint(os.Stdout)
+}
 
-	sr.MainReport.Print()
+// Fprint — выводит сводный отчёт по сайту в w
+func (sr *SiteReport) Fprint(w io.Writer) {
+	if sr.MainReport != nil {
+		sr.MainReport.Fprint(w)
+	}
+	if sr.Stopped != "" {
+		fmt.Fprintf(w, "\n%s\n", color.YellowString("⚠️  "+i18n.T("print.site.stopped."+sr.Stopped)))
+	}
 
 	if len(sr.SubReports) <= 1 {
+		printSitemap(w, sr.Sitemap)
+		printBaseline(w, sr.Baseline)
 		return
 	}
 
@@ -219,44 +247,24 @@ func (sr *SiteReport) Print() {
 	yellow := color.New(color.FgYellow).SprintFunc()
 	white := color.New(color.FgWhite).SprintFunc()
 
-	fmt.Println("\n" + cyan("🕷️ СВОДКА ПО САЙТУ"))
-	fmt.Printf("Просканировано: %s страниц\n", white(strconv.Itoa(len(sr.SubReports))))
-
-	var totalErrors, totalWarnings int
-	var missingTitles, missingH1, brokenPages int
+	fmt.Fprintln(w, "\n"+cyan("🕷️ "+i18n.T("print.site.header")))
+	fmt.Fprintln(w, i18n.T("print.site.crawled", white(strconv.Itoa(len(sr.SubReports)))))
 
-	for _, res := range sr.SubReports {
-		if res.Error != nil {
-			totalErrors++
-			continue
-		}
-		rep := res.Report
-		totalErrors += len(rep.Errors)
-		totalWarnings += len(rep.Warnings)
-		if rep.Title == "" {
-			missingTitles++
-		}
-		if rep.HeadingCounts["h1"] == 0 {
-			missingH1++
-		}
-		if rep.StatusCode >= 400 {
-			brokenPages++
-		}
-	}
+	sum := sr.Summary()
 
-	fmt.Printf("  Ошибок: %s, Предупреждений: %s\n",
-		red(strconv.Itoa(totalErrors)),
-		yellow(strconv.Itoa(totalWarnings)),
+	fmt.Fprintf(w, "  "+i18n.T("print.site.errors-warnings")+"\n",
+		red(strconv.Itoa(sum.Errors)),
+		yellow(strconv.Itoa(sum.Warnings)),
 	)
 
-	if missingTitles > 0 {
-		fmt.Printf("  ❗ %d страниц без <title>\n", missingTitles)
+	if sum.MissingTitles > 0 {
+		fmt.Fprintf(w, "  ❗ %s\n", i18n.T("print.site.missing-titles", sum.MissingTitles))
 	}
-	if missingH1 > 0 {
-		fmt.Printf("  ❗ %d страниц без <h1>\n", missingH1)
+	if sum.MissingH1 > 0 {
+		fmt.Fprintf(w, "  ❗ %s\n", i18n.T("print.site.missing-h1", sum.MissingH1))
 	}
-	if brokenPages > 0 {
-		fmt.Printf("  ❌ %d битых страниц (код ≥ 400)\n", brokenPages)
+	if sum.BrokenPages > 0 {
+		fmt.Fprintf(w, "  ❌ %s\n", i18n.T("print.site.broken", sum.BrokenPages))
 	}
 
 	type slowPage struct {
@@ -273,48 +281,148 @@ func (sr *SiteReport) Print() {
 		return slow[i].Time > slow[j].Time
 	})
 	if len(slow) > 0 && slow[0].Time > 2000 {
-		fmt.Print("\n  🐌 Медленные страницы (самые долгие):\n")
+		fmt.Fprintf(w, "\n  🐌 %s:\n", i18n.T("print.site.slow"))
 		for i := 0; i < 3 && i < len(slow); i++ {
 			if slow[i].Time > 2000 {
-				fmt.Printf("    %s — %s мс\n",
+				fmt.Fprintf(w, "    %s — %s\n",
 					strconvEllipsis(slow[i].URL, 40),
-					white(strconv.FormatInt(slow[i].Time, 10)),
+					i18n.T("print.ms", white(strconv.FormatInt(slow[i].Time, 10))),
 				)
 			}
 		}
 	}
 
-	warnFreq := make(map[string]int)
-	for _, res := range sr.SubReports {
-		if res.Report != nil {
-			for _, w := range res.Report.Warnings {
-				warnFreq[w]++
+	if len(sum.Rules) > 0 {
+		fmt.Fprintf(w, "\n  📉 %s:\n", i18n.T("print.site.top-rules"))
+		catalog := sr.catalog()
+		for i, rc := range sum.TopRules() {
+			if i >= 5 {
+				break
 			}
+			title := rc.RuleID
+			if info, ok := lookupRule(catalog, rc.RuleID); ok && info.Title != "" {
+				title = info.Title
+			}
+			fmt.Fprintf(w, "    • %s %s — %s (%dx)\n", title, grayf("[%s]", rc.RuleID), i18n.T("print.site.rule-pages", rc.Pages), rc.Count)
 		}
 	}
 
-	if len(warnFreq) > 0 {
-		fmt.Println("\n  📉 Самые частые предупреждения:")
+	printSitemap(w, sr.Sitemap)
+	printBaseline(w, sr.Baseline)
+
+	fmt.Fprintln(w, strings.Repeat("─", 65))
+
+}
+
+// printBaseline — выводит результат сравнения с базовой линией
+func printBaseline(w io.Writer, d *BaselineDelta) {
+	if d == nil {
+		return
+	}
+	cyan := color.New(color.FgCyan).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
 
-		type warnCount struct {
-			Text  string
-			Count int
+	fmt.Fprintln(w, "\n"+cyan("📌 "+i18n.T("print.baseline.header"))+" "+grayf("(%s)", d.File))
+	fmt.Fprintf(w, "  %s: %d\n", i18n.T("print.baseline.suppressed"), d.Suppressed)
+	if d.New > 0 {
+		fmt.Fprintf(w, "  %s: %s\n", i18n.T("print.baseline.new"), red(strconv.Itoa(d.New)))
+	} else {
+		fmt.Fprintf(w, "  %s: %s\n", i18n.T("print.baseline.new"), green("0"))
+	}
+	if len(d.Fixed) == 0 {
+		return
+	}
+	fixed := 0
+	for _, f := range d.Fixed {
+		fixed += f.Count
+	}
+	fmt.Fprintf(w, "  %s: %s\n", i18n.T("print.baseline.fixed"), green(strconv.Itoa(fixed)))
+	for i, f := range d.Fixed {
+		if i >= 10 {
+			fmt.Fprintf(w, "    %s\n", grayf("(+%d)", len(d.Fixed)-10))
+			break
 		}
-		var sorted []warnCount
-		for text, count := range warnFreq {
-			sorted = append(sorted, warnCount{text, count})
+		fmt.Fprintf(w, "    ✅ %s %s\n", strconvEllipsis(f.URL, 50), grayf("[%s]", f.RuleID))
+	}
+}
+
+// printSitemap — выводит покрытие сайта картами сайта
+func printSitemap(w io.Writer, sm *SitemapReport) {
+	if sm == nil {
+		return
+	}
+	cyan := color.New(color.FgCyan).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	fmt.Fprintln(w, "\n"+cyan("🗺️  "+i18n.T("print.sitemap.header")))
+	fmt.Fprintf(w, "  %s\n", i18n.T("print.sitemap.urls", sm.URLs, len(sm.Files)))
+	for _, f := range sm.Files {
+		if f.Error != "" {
+			fmt.Fprintf(w, "  ❌ %s %s\n", strconvEllipsis(f.URL, 50), grayf("(%s)", f.Error))
 		}
-		sort.Slice(sorted, func(i, j int) bool {
-			return sorted[i].Count > sorted[j].Count
-		})
+	}
 
-		for i := 0; i < 5 && i < len(sorted); i++ {
-			fmt.Printf("    • %s (%dx)\n", sorted[i].Text, sorted[i].Count)
+	list := func(title string, urls []string) {
+		if len(urls) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "  %s: %s\n", title, yellow(strconv.Itoa(len(urls))))
+		for i, u := range urls {
+			if i >= 5 {
+				fmt.Fprintf(w, "    %s\n", grayf("(+%d)", len(urls)-5))
+				break
+			}
+			fmt.Fprintf(w, "    • %s\n", strconvEllipsis(u, 60))
 		}
 	}
+	if len(sm.Issues) > 0 {
+		fmt.Fprintf(w, "  %s: %s\n", i18n.T("print.sitemap.issues"), yellow(strconv.Itoa(len(sm.Issues))))
+		for i, is := range sm.Issues {
+			if i >= 10 {
+				fmt.Fprintf(w, "    %s\n", grayf("(+%d)", len(sm.Issues)-10))
+				break
+			}
+			fmt.Fprintf(w, "    • %s — %s\n", strconvEllipsis(is.URL, 50), sitemapProblem(is))
+		}
+	}
+	list(i18n.T("print.sitemap.orphans"), sm.Orphans)
+	list(i18n.T("print.sitemap.unlisted"), sm.Unlisted)
+	if sm.Partial && len(sm.Orphans) > 0 {
+		fmt.Fprintf(w, "  %s\n", grayf("%s", i18n.T("print.sitemap.partial")))
+	}
+}
 
-	fmt.Println(strings.Repeat("─", 65))
+// sitemapProblem — описание проблемы записи карты сайта
+func sitemapProblem(is SitemapIssue) string {
+	if is.Detail != "" {
+		return i18n.T("sitemap.problem."+is.Problem, is.Detail)
+	}
+	return i18n.T("sitemap.problem." + is.Problem)
+}
 
+// printFindings — выводит замечания, схлопывая повторы одного правила в одну строку
+func printFindings(w io.Writer, indent string, findings []Finding) {
+	counts := make(map[string]int)
+	for _, f := range findings {
+		counts[f.RuleID]++
+	}
+	printed := make(map[string]bool)
+	for _, f := range findings {
+		if printed[f.RuleID] {
+			continue
+		}
+		if counts[f.RuleID] == 1 {
+			fmt.Fprintf(w, "%s• %s\n", indent, f.Message)
+			continue
+		}
+		printed[f.RuleID] = true
+		title := f.Message
+		if info, ok := LookupRule(f.RuleID); ok {
+			title = info.Title
+		}
+		fmt.Fprintf(w, "%s• %s (%dx)\n", indent, title, counts[f.RuleID])
+	}
 }
 
 func boolIcon(ok bool) string {
@@ -0,0 +1,183 @@
+package report
+
+import (
+	"sort"
+
+	"bullwler/internal/i18n"
+)
+
+// RuleInfo — описание правила аудита, по которому формируются замечания
+type RuleInfo struct {
+	ID          string   `json:"id"`
+	Category    Category `json:"category"`
+	Severity    Severity `json:"severity"`
+	Title       string   `json:"title"`
+	Remediation string   `json:"remediation"`
+}
+
+// skippedRuleID — правило, которым в SARIF и JUnit отмечаются страницы, пропущенные краулером
+const skippedRuleID = "network.page.skipped"
+
+// ruleCatalog — встроенные правила; названия и рекомендации берутся из каталога сообщений i18n
+var ruleCatalog = []RuleInfo{
+	// Загрузка страницы
+	{ID: "network.url.invalid", Category: CategoryNetwork, Severity: SeverityError},
+	{ID: "network.fetch.failed", Category: CategoryNetwork, Severity: SeverityError},
+	{ID: "network.body.unreadable", Category: CategoryNetwork, Severity: SeverityError},
+	{ID: "network.body.too-large", Category: CategoryNetwork, Severity: SeverityWarning},
+	{ID: "network.html.unparsable", Category: CategoryNetwork, Severity: SeverityError},
+	{ID: "network.status.not-ok", Category: CategoryNetwork, Severity: SeverityWarning},
+	{ID: "network.page.skipped", Category: CategoryNetwork, Severity: SeverityError},
+
+	// SEO
+	{ID: "seo.title.missing", Category: CategorySEO, Severity: SeverityWarning},
+	{ID: "seo.title.too-long", Category: CategorySEO, Severity: SeverityWarning},
+	{ID: "seo.description.missing", Category: CategorySEO, Severity: SeverityWarning},
+	{ID: "seo.description.too-long", Category: CategorySEO, Severity: SeverityWarning},
+	{ID: "seo.viewport.missing", Category: CategorySEO, Severity: SeverityWarning},
+	{ID: "seo.h1.missing", Category: CategorySEO, Severity: SeverityWarning},
+	{ID: "seo.h1.multiple", Category: CategorySEO, Severity: SeverityWarning},
+	{ID: "seo.main.missing", Category: CategorySEO, Severity: SeverityWarning},
+	{ID: "seo.opengraph.missing", Category: CategorySEO, Severity: SeverityInfo},
+	{ID: "seo.opengraph.incomplete", Category: CategorySEO, Severity: SeverityInfo},
+	{ID: "seo.twitter.missing", Category: CategorySEO, Severity: SeverityInfo},
+	{ID: "seo.twitter.card-missing", Category: CategorySEO, Severity: SeverityInfo},
+	{ID: "seo.structured-data.missing", Category: CategorySEO, Severity: SeverityWarning},
+	{ID: "seo.structured-data.invalid", Category: CategorySEO, Severity: SeverityWarning},
+	{ID: "seo.robots-txt.missing", Category: CategorySEO, Severity: SeverityInfo},
+	{ID: "seo.sitemap.missing", Category: CategorySEO, Severity: SeverityInfo},
+	{ID: "seo.sitemap.redirect", Category: CategorySEO, Severity: SeverityWarning},
+	{ID: "seo.sitemap.broken", Category: CategorySEO, Severity: SeverityError},
+	{ID: "seo.sitemap.noindex", Category: CategorySEO, Severity: SeverityWarning},
+	{ID: "seo.sitemap.orphan", Category: CategorySEO, Severity: SeverityWarning},
+	{ID: "seo.sitemap.unlisted", Category: CategorySEO, Severity: SeverityInfo},
+	{ID: "seo.redirect.chain", Category: CategorySEO, Severity: SeverityInfo},
+
+	// Доступность
+	{ID: "a11y.img.alt.missing", Category: CategoryA11y, Severity: SeverityError},
+	{ID: "a11y.img.alt.useless", Category: CategoryA11y, Severity: SeverityWarning},
+	{ID: "a11y.images.without-alt", Category: CategoryA11y, Severity: SeverityWarning},
+	{ID: "a11y.input.label.missing", Category: CategoryA11y, Severity: SeverityError},
+	{ID: "a11y.inputs.without-label", Category: CategoryA11y, Severity: SeverityWarning},
+	{ID: "a11y.inputs.without-name", Category: CategoryA11y, Severity: SeverityWarning},
+	{ID: "a11y.onclick.tabindex", Category: CategoryA11y, Severity: SeverityWarning},
+	{ID: "a11y.aria-labelledby.broken", Category: CategoryA11y, Severity: SeverityError},
+	{ID: "a11y.role.invalid", Category: CategoryA11y, Severity: SeverityWarning},
+	{ID: "a11y.role.required-attr", Category: CategoryA11y, Severity: SeverityError},
+
+	// Безопасность
+	{ID: "security.https.missing", Category: CategorySecurity, Severity: SeverityWarning},
+	{ID: "security.link.noopener", Category: CategorySecurity, Severity: SeverityWarning},
+	{ID: "security.mixed-content", Category: CategorySecurity, Severity: SeverityWarning},
+	{ID: "security.header.missing", Category: CategorySecurity, Severity: SeverityWarning},
+	{ID: "security.header.exposed", Category: CategorySecurity, Severity: SeverityWarning},
+	{ID: "security.header.xss-protection", Category: CategorySecurity, Severity: SeverityWarning},
+	{ID: "security.form.get-method", Category: CategorySecurity, Severity: SeverityInfo},
+	{ID: "security.form.insecure-action", Category: CategorySecurity, Severity: SeverityWarning},
+
+	// Производительность
+	{ID: "performance.response.slow", Category: CategoryPerformance, Severity: SeverityWarning},
+
+	// ИИ-дружелюбность
+	{ID: "ai.text-ratio.low", Category: CategoryAI, Severity: SeverityWarning},
+	{ID: "ai.date-published.missing", Category: CategoryAI, Severity: SeverityInfo},
+	{ID: "ai.direct-answer.missing", Category: CategoryAI, Severity: SeverityInfo},
+	{ID: "ai.text-density.low", Category: CategoryAI, Severity: SeverityWarning},
+
+	// Критерии AI Readiness Score
+	{ID: "ai.score.main", Category: CategoryAI, Severity: SeverityInfo},
+	{ID: "ai.score.json-ld", Category: CategoryAI, Severity: SeverityInfo},
+	{ID: "ai.score.summary", Category: CategoryAI, Severity: SeverityInfo},
+	{ID: "ai.score.canonical", Category: CategoryAI, Severity: SeverityInfo},
+	{ID: "ai.score.dates", Category: CategoryAI, Severity: SeverityInfo},
+	{ID: "ai.score.author", Category: CategoryAI, Severity: SeverityInfo},
+	{ID: "ai.score.text-volume", Category: CategoryAI, Severity: SeverityInfo},
+	{ID: "ai.score.text-ratio", Category: CategoryAI, Severity: SeverityInfo},
+	{ID: "ai.score.text-ratio-high", Category: CategoryAI, Severity: SeverityInfo},
+	{ID: "ai.score.lists-tables", Category: CategoryAI, Severity: SeverityInfo},
+	{ID: "ai.score.lang", Category: CategoryAI, Severity: SeverityInfo},
+	{ID: "ai.score.direct-answer", Category: CategoryAI, Severity: SeverityInfo},
+	{ID: "ai.score.text-density", Category: CategoryAI, Severity: SeverityInfo},
+	{ID: "ai.score.faq-howto", Category: CategoryAI, Severity: SeverityInfo},
+}
+
+// ruleIndex — встроенный каталог по идентификатору; после инициализации не изменяется.
+// Описания пользовательских правил хранятся в реестре, через который они проверяются,
+// и попадают в отчёты страниц полем SEOReport.Rules.
+var ruleIndex = func() map[string]RuleInfo {
+	m := make(map[string]RuleInfo, len(ruleCatalog))
+	for _, r := range ruleCatalog {
+		m[r.ID] = r
+	}
+	return m
+}()
+
+// LookupRule — возвращает описание встроенного правила по его идентификатору
+func LookupRule(id string) (RuleInfo, bool) {
+	r, ok := ruleIndex[id]
+	return Localize(r), ok
+}
+
+// Rules — возвращает встроенный каталог правил, отсортированный по идентификатору
+func Rules() []RuleInfo {
+	out := make([]RuleInfo, 0, len(ruleIndex))
+	for _, r := range ruleIndex {
+		out = append(out, Localize(r))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// Localize — подставляет название и рекомендацию правила на текущем языке.
+// Ключи каталога сообщений: rule.<id>.title и rule.<id>.fix; при отсутствии перевода
+// остаются значения из описания правила.
+func Localize(r RuleInfo) RuleInfo {
+	if title, ok := i18n.Lookup("rule." + r.ID + ".title"); ok {
+		r.Title = title
+	}
+	if fix, ok := i18n.Lookup("rule." + r.ID + ".fix"); ok {
+		r.Remediation = fix
+	}
+	return r
+}
+
+// catalog — каталог правил, по которому проверялась страница: Rules отчёта или встроенный
+func (r *SEOReport) catalog() []RuleInfo {
+	return localizeCatalog(r.Rules)
+}
+
+// localizeCatalog — локализованная копия каталога; для nil — встроенный каталог
+func localizeCatalog(rules []RuleInfo) []RuleInfo {
+	if rules == nil {
+		return Rules()
+	}
+	out := make([]RuleInfo, len(rules))
+	for i, info := range rules {
+		out[i] = Localize(info)
+	}
+	return out
+}
+
+// lookupRule — описание правила в каталоге проверки
+func lookupRule(catalog []RuleInfo, id string) (RuleInfo, bool) {
+	for _, info := range catalog {
+		if info.ID == id {
+			return info, true
+		}
+	}
+	return RuleInfo{}, false
+}
+
+// catalog — каталог правил сканирования: Rules сводного отчёта, а без него — каталог
+// первой проверенной в этом запуске страницы (реестр у всех страниц один)
+func (sr *SiteReport) catalog() []RuleInfo {
+	if sr.Rules != nil {
+		return localizeCatalog(sr.Rules)
+	}
+	for _, rep := range sr.Reports() {
+		if rep != nil && rep.Rules != nil {
+			return rep.catalog()
+		}
+	}
+	return Rules()
+}
@@ -0,0 +1,188 @@
+package report
+
+import "io"
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "bullwler"
+	toolInfoURI  = "https://github.com/advanceddev/bullwler"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	Help                 *sarifMessage      `json:"help,omitempty"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	Properties           sarifProperties    `json:"properties"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifProperties struct {
+	Category Category `json:"category"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifRegion struct {
+	Snippet sarifMessage `json:"snippet"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifBuilder — накапливает результаты и каталог правил для SARIF-лога
+type sarifBuilder struct {
+	rules   []sarifRule
+	index   map[string]int
+	results []sarifResult
+}
+
+// newSARIFBuilder — описания правил в tool.driver.rules берутся из каталога проверки
+func newSARIFBuilder(catalog []RuleInfo) *sarifBuilder {
+	b := &sarifBuilder{index: make(map[string]int)}
+	for _, info := range catalog {
+		b.addRule(info)
+	}
+	return b
+}
+
+func (b *sarifBuilder) addRule(info RuleInfo) int {
+	if idx, ok := b.index[info.ID]; ok {
+		return idx
+	}
+	rule := sarifRule{
+		ID:                   info.ID,
+		ShortDescription:     sarifMessage{Text: info.Title},
+		DefaultConfiguration: sarifConfiguration{Level: sarifLevel(info.Severity)},
+		Properties:           sarifProperties{Category: info.Category},
+	}
+	if info.Remediation != "" {
+		rule.Help = &sarifMessage{Text: info.Remediation}
+	}
+	b.index[info.ID] = len(b.rules)
+	b.rules = append(b.rules, rule)
+	return b.index[info.ID]
+}
+
+func (b *sarifBuilder) add(pageURL string, f Finding) {
+	idx, ok := b.index[f.RuleID]
+	if !ok {
+		idx = b.addRule(RuleInfo{ID: f.RuleID, Category: f.Category, Severity: f.Severity, Title: f.RuleID})
+	}
+	loc := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: pageURL}}
+	if f.Evidence != nil && f.Evidence.Snippet != "" {
+		loc.Region = &sarifRegion{Snippet: sarifMessage{Text: f.Evidence.Snippet}}
+	}
+	b.results = append(b.results, sarifResult{
+		RuleID:    f.RuleID,
+		RuleIndex: idx,
+		Level:     sarifLevel(f.Severity),
+		Message:   sarifMessage{Text: f.Message},
+		Locations: []sarifLocation{{PhysicalLocation: loc}},
+	})
+}
+
+func (b *sarifBuilder) addReport(r *SEOReport) {
+	for _, f := range r.Findings {
+		b.add(r.URL, f)
+	}
+}
+
+func (b *sarifBuilder) log() sarifLog {
+	results := b.results
+	if results == nil {
+		results = []sarifResult{}
+	}
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           toolName,
+				InformationURI: toolInfoURI,
+				Rules:          b.rules,
+			}},
+			Results: results,
+		}},
+	}
+}
+
+func sarifLevel(sev Severity) string {
+	switch sev {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// WriteSARIF — записывает замечания по странице в формате SARIF 2.1.0
+func (r *SEOReport) WriteSARIF(w io.Writer) error {
+	b := newSARIFBuilder(r.catalog())
+	b.addReport(r)
+	return writeJSON(w, b.log())
+}
+
+// WriteSARIF — записывает замечания по всем страницам сайта в формате SARIF 2.1.0
+func (sr *SiteReport) WriteSARIF(w io.Writer) error {
+	b := newSARIFBuilder(sr.catalog())
+	for _, res := range sr.Failed() {
+		b.add(res.URL, Finding{
+			RuleID:   skippedRuleID,
+			Severity: SeverityError,
+			Category: CategoryNetwork,
+			Message:  res.Error.Error(),
+		})
+	}
+	for _, r := range sr.Reports() {
+		b.addReport(r)
+	}
+	return writeJSON(w, b.log())
+}
@@ -1,5 +1,11 @@
 package report
 
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
 // CrawlResult — результат анализа одной страницы в рамках краулинга
 type CrawlResult struct {
 	URL    string
@@ -7,9 +13,206 @@ type CrawlResult struct {
 	Error  error
 }
 
+type crawlResultJSON struct {
+	URL    string     `json:"url"`
+	Error  string     `json:"error,omitempty"`
+	Report *SEOReport `json:"report,omitempty"`
+}
+
+// MarshalJSON — сериализует результат, представляя ошибку строкой
+func (cr CrawlResult) MarshalJSON() ([]byte, error) {
+	out := crawlResultJSON{URL: cr.URL, Report: cr.Report}
+	if cr.Error != nil {
+		out.Error = cr.Error.Error()
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON — восстанавливает результат из JSON
+func (cr *CrawlResult) UnmarshalJSON(data []byte) error {
+	var in crawlResultJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	cr.URL = in.URL
+	cr.Report = in.Report
+	cr.Error = nil
+	if in.Error != "" {
+		cr.Error = errors.New(in.Error)
+	}
+	return nil
+}
+
 // SiteReport — сводный отчёт по всему сайту
 type SiteReport struct {
-	MainURL    string
-	MainReport *SEOReport
-	SubReports []CrawlResult
+	MainURL    string        `json:"main_url"`
+	MainReport *SEOReport    `json:"main_report"`
+	SubReports []CrawlResult `json:"pages"`
+
+	// Baseline — сравнение с базовой линией (если она использовалась)
+	Baseline *BaselineDelta `json:"baseline,omitempty"`
+
+	// Stopped — причина досрочного завершения сканирования (StopCanceled, StopTimeout);
+	// пусто, если сайт просканирован в пределах заданных ограничений
+	Stopped string `json:"stopped,omitempty"`
+
+	// Sitemap — покрытие сайта картами сайта (если они использовались при сканировании)
+	Sitemap *SitemapReport `json:"sitemap,omitempty"`
+
+	// Rules — каталог включённых правил сканирования; не зависит от того, какие страницы
+	// проверены в этом запуске, а какие восстановлены из состояния. nil — встроенный каталог
+	// или каталог отчётов страниц.
+	Rules []RuleInfo `json:"-"`
+}
+
+// Причины досрочного завершения сканирования
+const (
+	// StopCanceled — сканирование отменено, например по Ctrl-C
+	StopCanceled = "canceled"
+	// StopTimeout — истёк общий лимит времени на сканирование
+	StopTimeout = "timeout"
+)
+
+// Reports — возвращает отчёты по всем проанализированным страницам, включая стартовую
+func (sr *SiteReport) Reports() []*SEOReport {
+	var out []*SEOReport
+	mainSeen := false
+	for _, res := range sr.SubReports {
+		if res.Report == nil {
+			continue
+		}
+		if res.Report == sr.MainReport {
+			mainSeen = true
+		}
+		out = append(out, res.Report)
+	}
+	if !mainSeen && sr.MainReport != nil {
+		out = append(out, sr.MainReport)
+	}
+	return out
+}
+
+// results — результаты по всем URL, включая главную страницу, если её нет среди подотчётов
+func (sr *SiteReport) results() []CrawlResult {
+	for _, res := range sr.SubReports {
+		if res.Report == sr.MainReport {
+			return sr.SubReports
+		}
+	}
+	if sr.MainReport == nil {
+		return sr.SubReports
+	}
+	return append(append([]CrawlResult{}, sr.SubReports...), CrawlResult{URL: sr.MainURL, Report: sr.MainReport})
+}
+
+// asSite — представляет отчёт одной страницы как отчёт сайта из одной страницы
+func (r *SEOReport) asSite() *SiteReport {
+	return &SiteReport{
+		MainURL:    r.URL,
+		MainReport: r,
+		SubReports: []CrawlResult{{URL: r.URL, Report: r}},
+		Baseline:   r.Baseline,
+	}
+}
+
+// Failed — возвращает результаты страниц, которые не удалось проанализировать
+func (sr *SiteReport) Failed() []CrawlResult {
+	var out []CrawlResult
+	for _, res := range sr.SubReports {
+		if res.Error != nil {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// SiteSummary — агрегированные показатели по сайту
+type SiteSummary struct {
+	Pages         int                  `json:"pages"`
+	Errors        int                  `json:"errors"`
+	Warnings      int                  `json:"warnings"`
+	MissingTitles int                  `json:"missing_titles"`
+	MissingH1     int                  `json:"missing_h1"`
+	BrokenPages   int                  `json:"broken_pages"`
+	Rules         map[string]RuleCount `json:"rules"`
+}
+
+// RuleCount — статистика срабатываний правила по сайту
+type RuleCount struct {
+	RuleID string `json:"rule_id"`
+	Count  int    `json:"count"`
+	Pages  int    `json:"pages"`
+}
+
+// Summary — считает агрегированные показатели по всем страницам
+func (sr *SiteReport) Summary() SiteSummary {
+	s := SiteSummary{Pages: len(sr.SubReports), Rules: make(map[string]RuleCount)}
+	for _, res := range sr.SubReports {
+		if res.Error != nil {
+			s.Errors++
+			continue
+		}
+		rep := res.Report
+		if rep == nil {
+			continue
+		}
+		s.Errors += rep.CountBySeverity(SeverityError)
+		s.Warnings += rep.CountBySeverity(SeverityWarning)
+		if rep.Title == "" {
+			s.MissingTitles++
+		}
+		if rep.HeadingCounts["h1"] == 0 {
+			s.MissingH1++
+		}
+		if rep.StatusCode >= 400 {
+			s.BrokenPages++
+		}
+
+		onPage := make(map[string]bool)
+		for _, f := range rep.Findings {
+			rc := s.Rules[f.RuleID]
+			rc.RuleID = f.RuleID
+			rc.Count++
+			if !onPage[f.RuleID] {
+				onPage[f.RuleID] = true
+				rc.Pages++
+			}
+			s.Rules[f.RuleID] = rc
+		}
+	}
+	return s
+}
+
+// TopRules — возвращает правила, отсортированные по числу затронутых страниц
+func (s SiteSummary) TopRules() []RuleCount {
+	out := make([]RuleCount, 0, len(s.Rules))
+	for _, rc := range s.Rules {
+		out = append(out, rc)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Pages != out[j].Pages {
+			return out[i].Pages > out[j].Pages
+		}
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].RuleID < out[j].RuleID
+	})
+	return out
+}
+
+// BaselineDelta — результат сравнения с базовой линией известных замечаний
+type BaselineDelta struct {
+	File       string         `json:"file"`
+	Suppressed int            `json:"suppressed"`
+	New        int            `json:"new"`
+	Fixed      []FixedFinding `json:"fixed"`
+}
+
+// FixedFinding — замечание из базовой линии, которое больше не воспроизводится
+type FixedFinding struct {
+	URL      string `json:"url"`
+	RuleID   string `json:"rule_id"`
+	Evidence string `json:"evidence,omitempty"`
+	Count    int    `json:"count"`
 }
@@ -0,0 +1,46 @@
+package report
+
+// SitemapReport — покрытие сайта картами сайта
+type SitemapReport struct {
+	// Files — загруженные файлы карт сайта, включая индексы
+	Files []SitemapFile `json:"files"`
+	// URLs — число уникальных URL во всех картах
+	URLs int `json:"urls"`
+	// Partial — сайт просканирован не полностью (лимиты страниц, глубины или времени),
+	// поэтому список Orphans может содержать страницы, ссылки на которые не найдены
+	Partial bool `json:"partial,omitempty"`
+	// Orphans — URL из карты сайта, на которые не ведёт ни одна найденная ссылка
+	Orphans []string `json:"orphans"`
+	// Unlisted — страницы, на которые есть ссылки, но которых нет в карте сайта
+	Unlisted []string `json:"unlisted"`
+	// Issues — записи карты сайта, которые не стоит отдавать поисковикам
+	Issues []SitemapIssue `json:"issues"`
+}
+
+// SitemapFile — файл карты сайта
+type SitemapFile struct {
+	URL   string `json:"url"`
+	Index bool   `json:"index,omitempty"`
+	URLs  int    `json:"urls"`
+	Error string `json:"error,omitempty"`
+}
+
+// SitemapIssue — проблема записи карты сайта
+type SitemapIssue struct {
+	URL     string `json:"url"`
+	Problem string `json:"problem"`
+	// Detail — конечный URL редиректа или код ответа
+	Detail string `json:"detail,omitempty"`
+}
+
+// Проблемы записей карты сайта
+const (
+	// SitemapRedirect — URL перенаправляет на другой адрес
+	SitemapRedirect = "redirect"
+	// SitemapBroken — URL отвечает кодом 4xx или 5xx
+	SitemapBroken = "broken"
+	// SitemapNoindex — страница запрещена к индексации meta robots или X-Robots-Tag
+	SitemapNoindex = "noindex"
+	// SitemapBlocked — URL закрыт в robots.txt
+	SitemapBlocked = "blocked"
+)
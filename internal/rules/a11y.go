@@ -0,0 +1,23 @@
+package rules
+
+import "bullwler/internal/i18n"
+
+func a11yRules() []Rule {
+	return []Rule{
+		builtin("a11y.images.without-alt", nil, func(c *Context) {
+			if n := c.Report.ImageWithoutAlt; n > 0 {
+				c.Add(i18n.T("msg.a11y.images.without-alt", n), nil)
+			}
+		}),
+		builtin("a11y.inputs.without-label", nil, func(c *Context) {
+			if n := c.Report.InputWithoutLabel; n > 0 {
+				c.Add(i18n.T("msg.a11y.inputs.without-label", n), nil)
+			}
+		}),
+		builtin("a11y.inputs.without-name", nil, func(c *Context) {
+			if n := c.Report.InputWithoutName; n > 0 {
+				c.Add(i18n.T("msg.a11y.inputs.without-name", n), nil)
+			}
+		}),
+	}
+}
@@ -0,0 +1,80 @@
+package rules
+
+import (
+	"strings"
+
+	"bullwler/internal/i18n"
+)
+
+func aiRules() []Rule {
+	return []Rule{
+		builtin("ai.text-ratio.low", map[string]float64{"min_ratio": 0.05}, func(c *Context) {
+			if limit := c.Param("min_ratio"); c.Report.TextToHTMLRatio < limit {
+				c.Add(i18n.T("msg.ai.text-ratio.low", limit*100), nil)
+			}
+		}),
+		builtin("ai.date-published.missing", nil, func(c *Context) {
+			if !c.Report.HasDatePublished {
+				c.Add(i18n.T("msg.ai.date-published.missing"), nil)
+			}
+		}),
+		builtin("ai.direct-answer.missing", nil, func(c *Context) {
+			if !c.Report.HasDirectAnswer && strings.HasSuffix(strings.TrimSpace(c.Report.Title), "?") {
+				c.Add(i18n.T("msg.ai.direct-answer.missing"), nil)
+			}
+		}),
+		builtin("ai.text-density.low", map[string]float64{"min_density": 0.4}, func(c *Context) {
+			if c.Report.TextDensityScore < c.Param("min_density") {
+				c.Add(i18n.T("msg.ai.text-density.low"), nil)
+			}
+		}),
+	}
+}
+
+// aiScoreSignals — критерии AI Readiness Score, каждый даёт один балл
+func aiScoreSignals() []Rule {
+	return []Rule{
+		signal("ai.score.main", nil, func(c *Context) bool {
+			return c.Report.HasMain
+		}),
+		signal("ai.score.json-ld", nil, func(c *Context) bool {
+			return c.Report.HasJSONLD && len(c.Report.SchemaOrgErrors) == 0
+		}),
+		signal("ai.score.summary", nil, func(c *Context) bool {
+			return c.Report.Description != "" || len(c.Report.HeadingTexts["h1"]) > 0
+		}),
+		signal("ai.score.canonical", nil, func(c *Context) bool {
+			return c.Report.HasCanonical
+		}),
+		signal("ai.score.dates", nil, func(c *Context) bool {
+			return c.Report.HasDatePublished || c.Report.HasDateModified
+		}),
+		signal("ai.score.author", nil, func(c *Context) bool {
+			return c.Report.HasAuthorWithName
+		}),
+		signal("ai.score.text-volume", map[string]float64{"min_text_bytes": 500}, func(c *Context) bool {
+			return float64(c.Report.TextBytes) > c.Param("min_text_bytes")
+		}),
+		signal("ai.score.text-ratio", map[string]float64{"min_ratio": 0.1}, func(c *Context) bool {
+			return c.Report.TextToHTMLRatio > c.Param("min_ratio")
+		}),
+		signal("ai.score.text-ratio-high", map[string]float64{"min_ratio": 0.2}, func(c *Context) bool {
+			return c.Report.TextToHTMLRatio > c.Param("min_ratio")
+		}),
+		signal("ai.score.lists-tables", nil, func(c *Context) bool {
+			return c.Report.ListCount > 0 || c.Report.TableCount > 0
+		}),
+		signal("ai.score.lang", nil, func(c *Context) bool {
+			return c.Report.HTMLLang != ""
+		}),
+		signal("ai.score.direct-answer", nil, func(c *Context) bool {
+			return c.Report.HasDirectAnswer
+		}),
+		signal("ai.score.text-density", map[string]float64{"min_density": 0.6}, func(c *Context) bool {
+			return c.Report.TextDensityScore > c.Param("min_density")
+		}),
+		signal("ai.score.faq-howto", nil, func(c *Context) bool {
+			return c.Report.HasFAQStructured || c.Report.HasHowToStructured
+		}),
+	}
+}
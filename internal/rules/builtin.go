@@ -0,0 +1,13 @@
+package rules
+
+// builtinRules — встроенные правила в порядке выполнения
+func builtinRules() []Rule {
+	var all []Rule
+	all = append(all, securityRules()...)
+	all = append(all, seoRules()...)
+	all = append(all, a11yRules()...)
+	all = append(all, performanceRules()...)
+	all = append(all, aiRules()...)
+	all = append(all, aiScoreSignals()...)
+	return all
+}
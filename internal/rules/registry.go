@@ -0,0 +1,240 @@
+package rules
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+
+	"bullwler/internal/i18n"
+	"bullwler/internal/report"
+
+	"golang.org/x/net/html"
+)
+
+// Registry — набор правил с переключателями и переопределёнными порогами
+type Registry struct {
+	mu       sync.RWMutex
+	rules    []Rule
+	index    map[string]int
+	disabled map[string]bool
+	params   map[string]map[string]float64
+	// catalog — кэш Catalog; сбрасывается при регистрации правил и переключении
+	catalog []report.RuleInfo
+}
+
+// NewRegistry — создаёт пустой реестр правил
+func NewRegistry() *Registry {
+	return &Registry{
+		index:    make(map[string]int),
+		disabled: make(map[string]bool),
+		params:   make(map[string]map[string]float64),
+	}
+}
+
+var (
+	defaultOnce     sync.Once
+	defaultRegistry *Registry
+)
+
+// Default — возвращает общий реестр со встроенными правилами
+func Default() *Registry {
+	defaultOnce.Do(func() {
+		defaultRegistry = NewRegistry()
+		for _, r := range builtinRules() {
+			if err := defaultRegistry.add(r); err != nil {
+				panic(err)
+			}
+		}
+	})
+	return defaultRegistry
+}
+
+// Register — добавляет пользовательское правило. Его описание хранится только в этом реестре
+// и его копиях, а не в общем каталоге: правило видно лишь проверкам с этим реестром.
+// Идентификаторы встроенных правил заняты.
+func (reg *Registry) Register(r Rule) error {
+	if _, builtin := report.LookupRule(r.Meta().ID); builtin {
+		return errors.New(i18n.T("err.rules.builtin", r.Meta().ID))
+	}
+	return reg.add(r)
+}
+
+func (reg *Registry) add(r Rule) error {
+	meta := r.Meta()
+	if meta.ID == "" {
+		return errors.New(i18n.T("err.rules.no-id"))
+	}
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	if _, exists := reg.index[meta.ID]; exists {
+		return errors.New(i18n.T("err.rules.duplicate", meta.ID))
+	}
+	reg.index[meta.ID] = len(reg.rules)
+	reg.rules = append(reg.rules, r)
+	reg.catalog = nil
+	return nil
+}
+
+// Catalog — описания включённых правил: встроенного каталога и зарегистрированных в реестре,
+// по идентификатору. Возвращается общий срез, который нельзя изменять.
+func (reg *Registry) Catalog() []report.RuleInfo {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	if reg.catalog != nil {
+		return reg.catalog
+	}
+	catalog := []report.RuleInfo{}
+	seen := make(map[string]bool)
+	for _, info := range report.Rules() {
+		seen[info.ID] = true
+		if !reg.disabled[info.ID] {
+			catalog = append(catalog, info)
+		}
+	}
+	for _, r := range reg.rules {
+		info := r.Meta().RuleInfo
+		if !seen[info.ID] && !reg.disabled[info.ID] {
+			catalog = append(catalog, info)
+		}
+	}
+	sort.Slice(catalog, func(i, j int) bool { return catalog[i].ID < catalog[j].ID })
+	reg.catalog = catalog
+	return catalog
+}
+
+// Clone — возвращает независимую копию реестра с теми же правилами и настройками
+func (reg *Registry) Clone() *Registry {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	c := NewRegistry()
+	c.rules = append(c.rules, reg.rules...)
+	for id, i := range reg.index {
+		c.index[id] = i
+	}
+	for id, off := range reg.disabled {
+		c.disabled[id] = off
+	}
+	for id, p := range reg.params {
+		c.params[id] = make(map[string]float64, len(p))
+		for k, v := range p {
+			c.params[id][k] = v
+		}
+	}
+	return c
+}
+
+// Rules — возвращает зарегистрированные правила в порядке регистрации
+func (reg *Registry) Rules() []Rule {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	out := make([]Rule, len(reg.rules))
+	copy(out, reg.rules)
+	return out
+}
+
+// Lookup — возвращает правило по идентификатору
+func (reg *Registry) Lookup(id string) (Rule, bool) {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	i, ok := reg.index[id]
+	if !ok {
+		return nil, false
+	}
+	return reg.rules[i], true
+}
+
+// SetEnabled — включает или выключает правило.
+// Выключить можно и правило, которое проверяется вне реестра (например, проверки
+// отдельных элементов DOM): его замечания будут удалены из отчёта.
+func (reg *Registry) SetEnabled(id string, enabled bool) error {
+	if _, ok := reg.Lookup(id); !ok {
+		if _, known := report.LookupRule(id); !known {
+			return errors.New(i18n.T("err.rules.unknown", id))
+		}
+	}
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	if enabled {
+		delete(reg.disabled, id)
+	} else {
+		reg.disabled[id] = true
+	}
+	reg.catalog = nil
+	return nil
+}
+
+// Enabled — проверяет, включено ли правило
+func (reg *Registry) Enabled(id string) bool {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	return !reg.disabled[id]
+}
+
+// SetParam — переопределяет порог правила
+func (reg *Registry) SetParam(id, name string, value float64) error {
+	r, ok := reg.Lookup(id)
+	if !ok {
+		return errors.New(i18n.T("err.rules.unknown", id))
+	}
+	if _, ok := r.Meta().Params[name]; !ok {
+		return errors.New(i18n.T("err.rules.unknown-param", id, name))
+	}
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	if reg.params[id] == nil {
+		reg.params[id] = make(map[string]float64)
+	}
+	reg.params[id][name] = value
+	return nil
+}
+
+// Param — возвращает действующее значение порога правила
+func (reg *Registry) Param(id, name string) float64 {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	if v, ok := reg.params[id][name]; ok {
+		return v
+	}
+	i, ok := reg.index[id]
+	if !ok {
+		return 0
+	}
+	return reg.rules[i].Meta().Params[name]
+}
+
+// Run — выполняет включённые правила и удаляет из отчёта замечания выключенных
+func (reg *Registry) Run(doc *html.Node, resp *http.Response, rep *report.SEOReport) {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	rep.AIScore = 0
+	rep.AIMaxScore = 0
+	for _, r := range reg.rules {
+		meta := r.Meta()
+		if reg.disabled[meta.ID] {
+			continue
+		}
+		rep.AIMaxScore += meta.Points
+		r.Check(&Context{
+			Doc:      doc,
+			Response: resp,
+			Report:   rep,
+			meta:     meta,
+			params:   reg.params[meta.ID],
+		})
+	}
+
+	if len(reg.disabled) == 0 {
+		return
+	}
+	kept := rep.Findings[:0]
+	for _, f := range rep.Findings {
+		if !reg.disabled[f.RuleID] {
+			kept = append(kept, f)
+		}
+	}
+	rep.Findings = kept
+}
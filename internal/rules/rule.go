@@ -0,0 +1,94 @@
+package rules
+
+import (
+	"net/http"
+
+	"bullwler/internal/report"
+
+	"golang.org/x/net/html"
+)
+
+// Meta — метаданные правила: описание из каталога, пороги по умолчанию
+// и вклад в AI Readiness Score
+type Meta struct {
+	report.RuleInfo
+
+	// Params — настраиваемые пороги правила и их значения по умолчанию
+	Params map[string]float64
+	// Points — сколько баллов AI Readiness Score даёт выполнение правила
+	Points int
+}
+
+// Rule — самостоятельная проверка страницы
+type Rule interface {
+	Meta() Meta
+	Check(c *Context)
+}
+
+// Context — данные, доступные правилу во время проверки
+type Context struct {
+	// Doc — разобранный DOM страницы (может быть nil, если HTML не разобран)
+	Doc *html.Node
+	// Response — HTTP-ответ сервера; тело к моменту проверки уже прочитано
+	Response *http.Response
+	// Report — отчёт по странице с собранными метриками
+	Report *report.SEOReport
+
+	meta   Meta
+	params map[string]float64
+}
+
+// Param — возвращает значение порога с учётом переопределений в реестре
+func (c *Context) Param(name string) float64 {
+	if v, ok := c.params[name]; ok {
+		return v
+	}
+	return c.meta.Params[name]
+}
+
+// Add — добавляет в отчёт замечание от имени текущего правила
+func (c *Context) Add(message string, ev *report.Evidence) {
+	c.Report.AddFinding(c.meta.ID, message, ev)
+}
+
+// Pass — засчитывает баллы AI Readiness Score текущего правила
+func (c *Context) Pass() {
+	c.Report.AIScore += c.meta.Points
+}
+
+// Header — возвращает заголовок HTTP-ответа или пустую строку
+func (c *Context) Header(name string) string {
+	if c.Response == nil {
+		return ""
+	}
+	return c.Response.Header.Get(name)
+}
+
+type funcRule struct {
+	meta  Meta
+	check func(c *Context)
+}
+
+func (r funcRule) Meta() Meta       { return r.meta }
+func (r funcRule) Check(c *Context) { r.check(c) }
+
+// New — создаёт правило из метаданных и функции проверки
+func New(meta Meta, check func(c *Context)) Rule {
+	return funcRule{meta: meta, check: check}
+}
+
+// builtin — создаёт встроенное правило, метаданные которого описаны в каталоге report
+func builtin(id string, params map[string]float64, check func(c *Context)) Rule {
+	info, _ := report.LookupRule(id)
+	return New(Meta{RuleInfo: info, Params: params}, check)
+}
+
+// signal — создаёт встроенный критерий AI Readiness Score
+func signal(id string, params map[string]float64, test func(c *Context) bool) Rule {
+	info, _ := report.LookupRule(id)
+	return New(Meta{RuleInfo: info, Params: params, Points: 1}, func(c *Context) {
+		if test(c) {
+			c.Pass()
+		}
+	})
+}
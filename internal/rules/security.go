@@ -0,0 +1,87 @@
+package rules
+
+import (
+	"bullwler/internal/i18n"
+	"bullwler/internal/report"
+)
+
+// recommendedHeaders — заголовки безопасности, которые должен отдавать сервер
+var recommendedHeaders = []string{
+	"Content-Security-Policy",
+	"X-Frame-Options",
+	"X-Content-Type-Options",
+	"Strict-Transport-Security",
+	"Referrer-Policy",
+	"Permissions-Policy",
+	"Cross-Origin-Opener-Policy",
+	"Cross-Origin-Embedder-Policy",
+}
+
+// exposingHeaders — заголовки, раскрывающие сведения о сервере
+var exposingHeaders = []string{"Server", "X-Powered-By"}
+
+func securityRules() []Rule {
+	return []Rule{
+		builtin("security.header.missing", nil, func(c *Context) {
+			if c.Response == nil {
+				return
+			}
+			for _, name := range recommendedHeaders {
+				if name == "Strict-Transport-Security" && !c.Report.IsHTTPS {
+					continue
+				}
+				val := c.Header(name)
+				present := val != ""
+				if name == "X-Content-Type-Options" {
+					present = val == "nosniff"
+				}
+				if present {
+					continue
+				}
+				c.Report.MissingSecurityHeaders = append(c.Report.MissingSecurityHeaders, name)
+				c.Add(i18n.T("msg.security.header.missing", name), &report.Evidence{Attribute: name})
+			}
+		}),
+		builtin("security.header.exposed", nil, func(c *Context) {
+			for _, name := range exposingHeaders {
+				if val := c.Header(name); val != "" {
+					c.Report.MissingSecurityHeaders = append(c.Report.MissingSecurityHeaders, i18n.T("msg.security.header.exposed.short", name))
+					c.Add(i18n.T("msg.security.header.exposed", name),
+						&report.Evidence{Attribute: name, Snippet: name + ": " + val})
+				}
+			}
+		}),
+		builtin("security.header.xss-protection", nil, func(c *Context) {
+			if val := c.Header("X-XSS-Protection"); val != "" && val != "0" {
+				c.Report.MissingSecurityHeaders = append(c.Report.MissingSecurityHeaders, i18n.T("msg.security.header.xss-protection"))
+				c.Add(i18n.T("msg.security.header.xss-protection"),
+					&report.Evidence{Attribute: "X-XSS-Protection", Snippet: "X-XSS-Protection: " + val})
+			}
+		}),
+		builtin("security.https.missing", nil, func(c *Context) {
+			if !c.Report.IsHTTPS {
+				c.Add(i18n.T("msg.security.https.missing"), nil)
+			}
+		}),
+		builtin("security.link.noopener", nil, func(c *Context) {
+			if n := c.Report.InsecureExternalLinks; n > 0 {
+				c.Add(i18n.T("msg.security.link.noopener", n), nil)
+			}
+		}),
+		builtin("security.mixed-content", nil, func(c *Context) {
+			if n := c.Report.InsecureResources; n > 0 {
+				c.Add(i18n.T("msg.security.mixed-content", n), nil)
+			}
+		}),
+		builtin("security.form.get-method", nil, func(c *Context) {
+			if n := c.Report.FormsWithGetMethod; n > 0 {
+				c.Add(i18n.T("msg.security.form.get-method", n), nil)
+			}
+		}),
+		builtin("security.form.insecure-action", nil, func(c *Context) {
+			if c.Report.InsecureFormActions > 0 {
+				c.Add(i18n.T("msg.security.form.insecure-action"), nil)
+			}
+		}),
+	}
+}
@@ -0,0 +1,125 @@
+package rules
+
+import (
+	"strings"
+
+	"bullwler/internal/i18n"
+	"bullwler/internal/report"
+)
+
+func seoRules() []Rule {
+	return []Rule{
+		builtin("seo.title.missing", nil, func(c *Context) {
+			if c.Report.TitleLength == 0 {
+				c.Add(i18n.T("msg.seo.title.missing"), nil)
+			}
+		}),
+		builtin("seo.title.too-long", map[string]float64{"max_length": 60}, func(c *Context) {
+			limit := int(c.Param("max_length"))
+			if c.Report.TitleLength > limit {
+				c.Add(i18n.T("msg.seo.title.too-long", limit),
+					&report.Evidence{Element: "title", Snippet: c.Report.Title})
+			}
+		}),
+		builtin("seo.description.missing", nil, func(c *Context) {
+			if c.Report.DescriptionLength == 0 {
+				c.Add(i18n.T("msg.seo.description.missing"), nil)
+			}
+		}),
+		builtin("seo.description.too-long", map[string]float64{"max_length": 160}, func(c *Context) {
+			limit := int(c.Param("max_length"))
+			if c.Report.DescriptionLength > limit {
+				c.Add(i18n.T("msg.seo.description.too-long", limit),
+					&report.Evidence{Element: "meta", Attribute: "description", Snippet: c.Report.Description})
+			}
+		}),
+		builtin("seo.viewport.missing", nil, func(c *Context) {
+			if !c.Report.HasViewport {
+				c.Add(i18n.T("msg.seo.viewport.missing"), nil)
+			}
+		}),
+		builtin("seo.h1.missing", nil, func(c *Context) {
+			if c.Report.HeadingCounts["h1"] == 0 {
+				c.Add(i18n.T("msg.seo.h1.missing"), nil)
+			}
+		}),
+		builtin("seo.h1.multiple", nil, func(c *Context) {
+			if c.Report.HeadingCounts["h1"] > 1 {
+				c.Add(i18n.T("msg.seo.h1.multiple"),
+					&report.Evidence{Element: "h1", Snippet: strings.Join(c.Report.HeadingTexts["h1"], " | ")})
+			}
+		}),
+		builtin("seo.main.missing", nil, func(c *Context) {
+			if !c.Report.HasMain {
+				c.Add(i18n.T("msg.seo.main.missing"), nil)
+			}
+		}),
+		builtin("seo.opengraph.missing", nil, func(c *Context) {
+			if len(c.Report.OG) == 0 {
+				c.Add(i18n.T("msg.seo.opengraph.missing"), nil)
+			}
+		}),
+		builtin("seo.opengraph.incomplete", nil, func(c *Context) {
+			if len(c.Report.OG) == 0 {
+				return
+			}
+			missing := []string{}
+			for _, k := range []string{"title", "description", "image"} {
+				if c.Report.OG[k] == "" {
+					missing = append(missing, k)
+				}
+			}
+			if len(missing) > 0 {
+				c.Add(i18n.T("msg.seo.opengraph.incomplete", strings.Join(missing, ", ")),
+					&report.Evidence{Element: "meta", Attribute: "og:" + strings.Join(missing, ",og:")})
+			}
+		}),
+		builtin("seo.twitter.missing", nil, func(c *Context) {
+			if len(c.Report.Twitter) == 0 {
+				c.Add(i18n.T("msg.seo.twitter.missing"), nil)
+			}
+		}),
+		builtin("seo.twitter.card-missing", nil, func(c *Context) {
+			if len(c.Report.Twitter) > 0 && c.Report.Twitter["card"] == "" {
+				c.Add(i18n.T("msg.seo.twitter.card-missing"), nil)
+			}
+		}),
+		builtin("seo.structured-data.missing", nil, func(c *Context) {
+			if !c.Report.HasJSONLD && !c.Report.HasMicrodata && !c.Report.HasRDFa {
+				c.Add(i18n.T("msg.seo.structured-data.missing"), nil)
+			}
+		}),
+		builtin("seo.structured-data.invalid", nil, func(c *Context) {
+			if errs := c.Report.SchemaOrgErrors; len(errs) > 0 {
+				c.Add(i18n.T("msg.seo.structured-data.invalid", strings.Join(errs, "; ")),
+					&report.Evidence{Element: "script", Attribute: "application/ld+json", Snippet: strings.Join(errs, "; ")})
+			}
+		}),
+		builtin("seo.robots-txt.missing", nil, func(c *Context) {
+			if !c.Report.HasRobotsTxt {
+				c.Add(i18n.T("msg.seo.robots-txt.missing"), nil)
+			}
+		}),
+		builtin("seo.sitemap.missing", nil, func(c *Context) {
+			if !c.Report.HasSitemap {
+				c.Add(i18n.T("msg.seo.sitemap.missing"), nil)
+			}
+		}),
+		builtin("seo.redirect.chain", map[string]float64{"max_redirects": 0}, func(c *Context) {
+			if n := len(c.Report.Redirects); n > int(c.Param("max_redirects")) {
+				c.Add(i18n.T("msg.seo.redirect.chain", n),
+					&report.Evidence{Snippet: strings.Join(c.Report.Redirects, " → ")})
+			}
+		}),
+	}
+}
+
+func performanceRules() []Rule {
+	return []Rule{
+		builtin("performance.response.slow", map[string]float64{"max_ms": 3000}, func(c *Context) {
+			if c.Report.ResponseTimeMs > int64(c.Param("max_ms")) {
+				c.Add(i18n.T("msg.performance.response.slow", c.Report.ResponseTimeMs), nil)
+			}
+		}),
+	}
+}
@@ -0,0 +1,168 @@
+package sitemap
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"bullwler/internal/fetch"
+	"bullwler/internal/i18n"
+	"bullwler/internal/report"
+)
+
+// fileTimeout — таймаут загрузки одного файла карты сайта
+const fileTimeout = 30 * time.Second
+
+// maxFiles — сколько файлов карт сайта загружается не более, с учётом вложенных индексов
+const maxFiles = 1000
+
+// File — загруженный файл карты сайта
+type File struct {
+	URL   string
+	Index bool
+	URLs  int
+	// Err — ошибка загрузки или разбора; записи такого файла не учитываются
+	Err error
+}
+
+// Set — записи всех карт сайта, найденных от заданных точек входа
+type Set struct {
+	Files []File
+	// URLs — записи без повторов, в порядке появления
+	URLs []URL
+}
+
+// Load — загружает карты сайта по адресам locations, рекурсивно раскрывая индексы.
+// Ошибки отдельных файлов записываются в File.Err и не прерывают загрузку остальных.
+func Load(ctx context.Context, f *fetch.Fetcher, locations []string) *Set {
+	set := &Set{}
+	seenFiles := make(map[string]bool)
+	seenURLs := make(map[string]bool)
+
+	queue := append([]string(nil), locations...)
+	for len(queue) > 0 && len(set.Files) < maxFiles && ctx.Err() == nil {
+		loc := queue[0]
+		queue = queue[1:]
+		if seenFiles[loc] {
+			continue
+		}
+		seenFiles[loc] = true
+
+		file := File{URL: loc}
+		doc, err := get(ctx, f, loc)
+		if err != nil {
+			file.Err = err
+			set.Files = append(set.Files, file)
+			continue
+		}
+
+		file.Index = doc.Index
+		if doc.Index {
+			file.URLs = len(doc.Sitemaps)
+			queue = append(queue, doc.Sitemaps...)
+		} else {
+			file.URLs = len(doc.URLs)
+			for _, u := range doc.URLs {
+				if u.Loc == "" || seenURLs[u.Loc] {
+					continue
+				}
+				seenURLs[u.Loc] = true
+				set.URLs = append(set.URLs, u)
+			}
+		}
+		set.Files = append(set.Files, file)
+	}
+	return set
+}
+
+// Check — загружает и проверяет карту сайта по адресу location, а если это индекс —
+// и все вложенные карты. Записи должны лежать на хосте карты, в которой они перечислены.
+func Check(ctx context.Context, f *fetch.Fetcher, location string) []*Validation {
+	var out []*Validation
+	seen := map[string]bool{location: true}
+	type item struct {
+		loc    string
+		nested bool
+	}
+	queue := []item{{loc: location}}
+	for len(queue) > 0 && len(out) < maxFiles && ctx.Err() == nil {
+		it := queue[0]
+		queue = queue[1:]
+
+		var v *Validation
+		data, err := download(ctx, f, it.loc)
+		if err != nil {
+			v = &Validation{File: it.loc, Problems: []Problem{}}
+			v.add(report.SeverityError, CheckFetch, 0, "", err)
+		} else {
+			v = Validate(it.loc, data, fileScope(it.loc))
+		}
+		out = append(out, v)
+
+		if !v.Index {
+			continue
+		}
+		// Индекс может ссылаться только на файлы urlset: вложенные индексы поисковики не читают
+		if it.nested {
+			v.add(report.SeverityError, CheckNestedIndex, 0, "")
+			continue
+		}
+		for _, child := range v.Sitemaps {
+			if !seen[child] {
+				seen[child] = true
+				queue = append(queue, item{loc: child, nested: true})
+			}
+		}
+	}
+	return out
+}
+
+// fileScope — область URL для карты по адресу loc: её хост и каталог
+func fileScope(loc string) *url.URL {
+	u, err := url.Parse(loc)
+	if err != nil {
+		return nil
+	}
+	dir := path.Dir(u.Path)
+	if !strings.HasSuffix(dir, "/") {
+		dir += "/"
+	}
+	return &url.URL{Scheme: u.Scheme, Host: u.Host, Path: dir}
+}
+
+// get — загружает и разбирает один файл
+func get(ctx context.Context, f *fetch.Fetcher, loc string) (*Document, error) {
+	data, err := download(ctx, f, loc)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// download — загружает один файл; тело читается не больше чем на байт сверх MaxFileSize,
+// чтобы превышение лимита было видно
+func download(ctx context.Context, f *fetch.Fetcher, loc string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, fileTimeout)
+	defer cancel()
+
+	req, err := f.NewRequest(ctx, http.MethodGet, loc)
+	if err != nil {
+		return nil, err
+	}
+	// Файл читается через Do, а не Get: допустимый размер карты больше ограничения тела страницы
+	resp, err := f.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New(i18n.T("err.http-status", resp.StatusCode))
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, MaxFileSize+1))
+}
@@ -0,0 +1,103 @@
+package sitemap
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"bullwler/internal/i18n"
+	"bullwler/internal/report"
+
+	"github.com/fatih/color"
+)
+
+// problemsShown — сколько проблем одного файла выводить в терминал
+const problemsShown = 50
+
+// Result — итог проверки карты сайта и вложенных в индекс карт
+type Result struct {
+	Files    []*Validation `json:"files"`
+	Errors   int           `json:"errors"`
+	Warnings int           `json:"warnings"`
+}
+
+// NewResult — собирает итог по проверенным файлам
+func NewResult(files []*Validation) *Result {
+	r := &Result{Files: files}
+	if r.Files == nil {
+		r.Files = []*Validation{}
+	}
+	for _, v := range files {
+		r.Errors += v.Count(report.SeverityError)
+		r.Warnings += v.Count(report.SeverityWarning)
+	}
+	return r
+}
+
+// WriteJSON — записывает итог проверки в формате JSON
+func (r *Result) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(r)
+}
+
+// Fprint — выводит итог проверки в терминальном виде
+func (r *Result) Fprint(w io.Writer) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	gray := color.New(color.FgHiBlack).SprintFunc()
+
+	fmt.Fprintln(w, cyan("\n🗺️  "+i18n.T("print.sitemap.check.header")))
+	fmt.Fprintln(w, strings.Repeat("─", 65))
+	for _, v := range r.Files {
+		kind := i18n.T("print.sitemap.check.urlset", v.Entries)
+		if v.Index {
+			kind = i18n.T("print.sitemap.check.index", v.Entries)
+		}
+		status := green("✅")
+		if v.Count(report.SeverityError) > 0 {
+			status = red("❌")
+		} else if v.Count(report.SeverityWarning) > 0 {
+			status = yellow("⚠️ ")
+		}
+		size := fmt.Sprintf("%.1f KiB", float64(v.Size)/1024)
+		if v.Compressed {
+			size += ", gzip"
+		}
+		if v.Size > 0 {
+			fmt.Fprintf(w, "%s %s %s\n", status, v.File, gray("("+kind+", "+size+")"))
+		} else {
+			fmt.Fprintf(w, "%s %s\n", status, v.File)
+		}
+
+		for i, p := range v.Problems {
+			if i == problemsShown {
+				fmt.Fprintf(w, "    %s\n", gray(i18n.T("print.sitemap.check.more", len(v.Problems)-problemsShown)))
+				break
+			}
+			mark := yellow("•")
+			if p.Severity == report.SeverityError {
+				mark = red("•")
+			}
+			where := ""
+			if p.Line > 0 {
+				where = gray(fmt.Sprintf("%s %d: ", i18n.T("print.sitemap.check.line"), p.Line))
+			}
+			fmt.Fprintf(w, "    %s %s%s %s\n", mark, where, p.Message, gray("["+p.Code+"]"))
+			if p.Loc != "" {
+				fmt.Fprintf(w, "      %s\n", gray(p.Loc))
+			}
+		}
+	}
+	fmt.Fprintln(w, strings.Repeat("─", 65))
+	summary := i18n.T("print.sitemap.check.summary", len(r.Files), r.Errors, r.Warnings)
+	if r.Errors > 0 {
+		fmt.Fprintln(w, red(summary))
+	} else {
+		fmt.Fprintln(w, green(summary))
+	}
+}
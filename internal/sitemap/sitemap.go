@@ -0,0 +1,136 @@
+// Package sitemap — карты сайта по протоколу sitemaps.org: разбор urlset, индексов
+// и сжатых gzip файлов, проверка на соответствие протоколу и запись новых карт.
+package sitemap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+
+	"bullwler/internal/i18n"
+)
+
+// MaxURLs — наибольшее число URL в одном файле по протоколу
+const MaxURLs = 50000
+
+// MaxFileSize — наибольший размер файла без сжатия по протоколу, 50 МиБ
+const MaxFileSize = 50 << 20
+
+// Пространства имён протокола и расширений Google
+const (
+	Namespace      = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	ImageNamespace = "http://www.google.com/schemas/sitemap-image/1.1"
+	VideoNamespace = "http://www.google.com/schemas/sitemap-video/1.1"
+	NewsNamespace  = "http://www.google.com/schemas/sitemap-news/0.9"
+)
+
+// URL — запись <url> карты сайта
+type URL struct {
+	Loc        string  `xml:"loc"`
+	LastMod    string  `xml:"lastmod,omitempty"`
+	ChangeFreq string  `xml:"changefreq,omitempty"`
+	Priority   string  `xml:"priority,omitempty"`
+	Images     []Image `xml:"http://www.google.com/schemas/sitemap-image/1.1 image"`
+	Videos     []Video `xml:"http://www.google.com/schemas/sitemap-video/1.1 video"`
+	News       []News  `xml:"http://www.google.com/schemas/sitemap-news/0.9 news"`
+}
+
+// Image — расширение <image:image>
+type Image struct {
+	Loc string `xml:"loc"`
+}
+
+// Video — расширение <video:video>
+type Video struct {
+	ThumbnailLoc    string `xml:"thumbnail_loc"`
+	Title           string `xml:"title"`
+	Description     string `xml:"description"`
+	ContentLoc      string `xml:"content_loc"`
+	PlayerLoc       string `xml:"player_loc"`
+	Duration        string `xml:"duration"`
+	ExpirationDate  string `xml:"expiration_date"`
+	Rating          string `xml:"rating"`
+	PublicationDate string `xml:"publication_date"`
+	FamilyFriendly  string `xml:"family_friendly"`
+}
+
+// News — расширение <news:news>
+type News struct {
+	Publication struct {
+		Name     string `xml:"name"`
+		Language string `xml:"language"`
+	} `xml:"publication"`
+	PublicationDate string `xml:"publication_date"`
+	Title           string `xml:"title"`
+}
+
+// Document — разобранный файл: список URL (urlset) или индекс других карт (sitemapindex)
+type Document struct {
+	// Index — файл является индексом карт сайта
+	Index bool
+	// URLs — записи <url> для urlset
+	URLs []URL
+	// Sitemaps — адреса вложенных карт для индекса
+	Sitemaps []string
+	// Size — размер файла без сжатия
+	Size int
+}
+
+// Parse — разбирает файл карты сайта; сжатые gzip данные распаковываются
+func Parse(data []byte) (*Document, error) {
+	data, err := decompress(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var root struct {
+		XMLName  xml.Name
+		URLs     []URL `xml:"url"`
+		Sitemaps []struct {
+			Loc string `xml:"loc"`
+		} `xml:"sitemap"`
+	}
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.sitemap.xml"), err)
+	}
+
+	doc := &Document{Size: len(data)}
+	switch root.XMLName.Local {
+	case "urlset":
+		doc.URLs = root.URLs
+	case "sitemapindex":
+		doc.Index = true
+		for _, s := range root.Sitemaps {
+			doc.Sitemaps = append(doc.Sitemaps, s.Loc)
+		}
+	default:
+		return nil, errors.New(i18n.T("err.sitemap.root", root.XMLName.Local))
+	}
+	return doc, nil
+}
+
+// decompress — распаковывает gzip по сигнатуре, а не по расширению: серверы отдают
+// .xml.gz и с Content-Encoding, и без него
+func decompress(data []byte) ([]byte, error) {
+	if !gzipped(data) {
+		return data, nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.sitemap.gzip"), err)
+	}
+	defer zr.Close()
+	out, err := io.ReadAll(io.LimitReader(zr, MaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.sitemap.gzip"), err)
+	}
+	return out, nil
+}
+
+// gzipped — данные начинаются с сигнатуры gzip
+func gzipped(data []byte) bool {
+	return len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b
+}
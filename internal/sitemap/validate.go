@@ -0,0 +1,399 @@
+package sitemap
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"bullwler/internal/i18n"
+	"bullwler/internal/report"
+)
+
+// Ограничения протокола и расширений Google
+const (
+	// MaxLocLength — наибольшая длина URL в <loc>
+	MaxLocLength = 2048
+	// MaxImagesPerURL — наибольшее число <image:image> в одной записи
+	MaxImagesPerURL = 1000
+	// MaxNewsURLs — наибольшее число записей <news:news> в одном файле
+	MaxNewsURLs = 1000
+	// MaxVideoDuration — наибольшая длительность видео в секундах, 8 часов
+	MaxVideoDuration = 28800
+)
+
+// Коды проблем проверки; текст сообщения — ключ каталога "sitemap.check.<код>"
+const (
+	CheckFetch         = "fetch"
+	CheckXML           = "xml"
+	CheckRoot          = "root"
+	CheckNamespace     = "namespace"
+	CheckEmpty         = "empty"
+	CheckTooLarge      = "too-large"
+	CheckTooManyURLs   = "too-many-urls"
+	CheckNestedIndex   = "nested-index"
+	CheckLocMissing    = "loc.missing"
+	CheckLocInvalid    = "loc.invalid"
+	CheckLocHost       = "loc.host"
+	CheckLocScope      = "loc.scope"
+	CheckLocTooLong    = "loc.too-long"
+	CheckLocDuplicate  = "loc.duplicate"
+	CheckLastMod       = "lastmod.invalid"
+	CheckLastModFuture = "lastmod.future"
+	CheckChangeFreq    = "changefreq.invalid"
+	CheckPriority      = "priority.invalid"
+	CheckImageLoc      = "image.loc"
+	CheckImageTooMany  = "image.too-many"
+	CheckVideoRequired = "video.required"
+	CheckVideoContent  = "video.content"
+	CheckVideoLoc      = "video.loc"
+	CheckVideoDuration = "video.duration"
+	CheckVideoRating   = "video.rating"
+	CheckVideoDate     = "video.date"
+	CheckVideoFamily   = "video.family-friendly"
+	CheckNewsRequired  = "news.required"
+	CheckNewsLanguage  = "news.language"
+	CheckNewsDate      = "news.date"
+	CheckNewsTooMany   = "news.too-many"
+)
+
+// Problem — нарушение протокола в файле карты сайта
+type Problem struct {
+	Code     string          `json:"code"`
+	Severity report.Severity `json:"severity"`
+	// Line — строка файла, в которой начинается запись; 0 для проблем всего файла
+	Line    int    `json:"line,omitempty"`
+	Loc     string `json:"loc,omitempty"`
+	Message string `json:"message"`
+}
+
+// Validation — результат проверки одного файла карты сайта
+type Validation struct {
+	// File — адрес или путь к файлу
+	File       string    `json:"file"`
+	Index      bool      `json:"index,omitempty"`
+	Entries    int       `json:"entries"`
+	Size       int       `json:"size"`
+	Compressed bool      `json:"compressed,omitempty"`
+	Problems   []Problem `json:"problems"`
+	// Sitemaps — адреса вложенных карт, если файл является индексом
+	Sitemaps []string `json:"-"`
+}
+
+// Count — число проблем заданного уровня
+func (v *Validation) Count(sev report.Severity) int {
+	n := 0
+	for _, p := range v.Problems {
+		if p.Severity == sev {
+			n++
+		}
+	}
+	return n
+}
+
+func (v *Validation) add(sev report.Severity, code string, line int, loc string, args ...any) {
+	v.Problems = append(v.Problems, Problem{
+		Code:     code,
+		Severity: sev,
+		Line:     line,
+		Loc:      loc,
+		Message:  i18n.T("sitemap.check."+code, args...),
+	})
+}
+
+var (
+	changeFreqs  = map[string]bool{"always": true, "hourly": true, "daily": true, "weekly": true, "monthly": true, "yearly": true, "never": true}
+	newsLanguage = regexp.MustCompile(`^([a-z]{2,3}|zh-cn|zh-tw)$`)
+	// lastModLayouts — форматы W3C Datetime, допустимые в <lastmod>
+	lastModLayouts = []string{"2006", "2006-01", "2006-01-02", "2006-01-02T15:04Z07:00", time.RFC3339Nano}
+)
+
+// Validate — проверяет файл карты сайта на соответствие протоколу sitemaps.org и расширениям
+// image, video и news. scope задаёт, где могут лежать URL из файла: записи должны быть на его
+// хосте (и схеме, если она задана), а непустой путь ограничивает их каталогом, в котором лежит
+// карта. Если scope равен nil, записи сравниваются с хостом первой из них.
+// Сжатые gzip данные распаковываются.
+func Validate(name string, data []byte, scope *url.URL) *Validation {
+	v := &Validation{File: name, Problems: []Problem{}}
+	v.Compressed = gzipped(data)
+	data, err := decompress(data)
+	if err != nil {
+		v.add(report.SeverityError, CheckXML, 0, "", err)
+		return v
+	}
+	v.Size = len(data)
+	if v.Size > MaxFileSize {
+		v.add(report.SeverityError, CheckTooLarge, 0, "", MaxFileSize>>20)
+		return v
+	}
+
+	(&validator{v: v, scope: scope, seen: make(map[string]bool), now: time.Now()}).run(data)
+	return v
+}
+
+// validator — состояние потоковой проверки одного файла
+type validator struct {
+	v     *Validation
+	scope *url.URL
+	seen  map[string]bool
+	now   time.Time
+	news  int
+}
+
+func (c *validator) run(data []byte) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	root, err := nextStart(dec)
+	if err != nil {
+		c.v.add(report.SeverityError, CheckXML, line(dec), "", err)
+		return
+	}
+
+	var entry string
+	switch root.Name.Local {
+	case "urlset":
+		entry = "url"
+	case "sitemapindex":
+		entry = "sitemap"
+		c.v.Index = true
+	default:
+		c.v.add(report.SeverityError, CheckRoot, line(dec), "", root.Name.Local)
+		return
+	}
+	if root.Name.Space != Namespace {
+		c.v.add(report.SeverityWarning, CheckNamespace, line(dec), "", root.Name.Space)
+	}
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			c.v.add(report.SeverityError, CheckXML, line(dec), "", err)
+			return
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if se.Name.Local != entry {
+			if err := dec.Skip(); err != nil {
+				c.v.add(report.SeverityError, CheckXML, line(dec), "", err)
+				return
+			}
+			continue
+		}
+
+		ln := line(dec)
+		var u URL
+		if err := dec.DecodeElement(&u, &se); err != nil {
+			c.v.add(report.SeverityError, CheckXML, line(dec), "", err)
+			return
+		}
+		c.v.Entries++
+		c.entry(ln, u)
+	}
+
+	if c.v.Entries > MaxURLs {
+		c.v.add(report.SeverityError, CheckTooManyURLs, 0, "", c.v.Entries, MaxURLs)
+	}
+	if c.news > MaxNewsURLs {
+		c.v.add(report.SeverityError, CheckNewsTooMany, 0, "", c.news, MaxNewsURLs)
+	}
+	if c.v.Entries == 0 {
+		c.v.add(report.SeverityWarning, CheckEmpty, 0, "")
+	}
+}
+
+// entry — проверяет запись <url> или <sitemap>
+func (c *validator) entry(ln int, u URL) {
+	loc := strings.TrimSpace(u.Loc)
+	if c.checkLoc(ln, loc) {
+		if c.seen[loc] {
+			c.v.add(report.SeverityWarning, CheckLocDuplicate, ln, loc)
+		}
+		c.seen[loc] = true
+		if c.v.Index {
+			c.v.Sitemaps = append(c.v.Sitemaps, loc)
+		}
+	}
+
+	if s := strings.TrimSpace(u.LastMod); s != "" {
+		if t, ok := parseW3CTime(s); !ok {
+			c.v.add(report.SeverityError, CheckLastMod, ln, loc, s)
+		} else if t.After(c.now.Add(24 * time.Hour)) {
+			c.v.add(report.SeverityWarning, CheckLastModFuture, ln, loc, s)
+		}
+	}
+	if c.v.Index {
+		return
+	}
+
+	if s := strings.TrimSpace(u.ChangeFreq); s != "" && !changeFreqs[s] {
+		c.v.add(report.SeverityError, CheckChangeFreq, ln, loc, s)
+	}
+	if s := strings.TrimSpace(u.Priority); s != "" {
+		if p, err := strconv.ParseFloat(s, 64); err != nil || p < 0 || p > 1 {
+			c.v.add(report.SeverityError, CheckPriority, ln, loc, s)
+		}
+	}
+
+	if len(u.Images) > MaxImagesPerURL {
+		c.v.add(report.SeverityError, CheckImageTooMany, ln, loc, len(u.Images), MaxImagesPerURL)
+	}
+	for _, img := range u.Images {
+		// Изображения могут лежать на CDN, поэтому хост не проверяется
+		if !absoluteURL(img.Loc) {
+			c.v.add(report.SeverityError, CheckImageLoc, ln, loc, strings.TrimSpace(img.Loc))
+		}
+	}
+	for _, video := range u.Videos {
+		c.video(ln, loc, video)
+	}
+	if len(u.News) > 0 {
+		c.news++
+	}
+	for _, news := range u.News {
+		c.newsEntry(ln, loc, news)
+	}
+}
+
+// checkLoc — проверяет адрес записи; возвращает true, если адрес корректен
+func (c *validator) checkLoc(ln int, loc string) bool {
+	if loc == "" {
+		c.v.add(report.SeverityError, CheckLocMissing, ln, "")
+		return false
+	}
+	u, err := url.Parse(loc)
+	if err != nil || !absoluteURL(loc) {
+		c.v.add(report.SeverityError, CheckLocInvalid, ln, loc)
+		return false
+	}
+	if len(loc) > MaxLocLength {
+		c.v.add(report.SeverityError, CheckLocTooLong, ln, loc, MaxLocLength)
+	}
+	// Без заданной области все записи сравниваются с хостом первой из них
+	if c.scope == nil {
+		c.scope = &url.URL{Scheme: u.Scheme, Host: u.Host}
+	}
+	if !strings.EqualFold(u.Host, c.scope.Host) || (c.scope.Scheme != "" && u.Scheme != c.scope.Scheme) {
+		c.v.add(report.SeverityError, CheckLocHost, ln, loc, scopeRoot(c.scope))
+		return true
+	}
+	if dir := c.scope.Path; dir != "" && dir != "/" && !strings.HasPrefix(u.Path, dir) {
+		c.v.add(report.SeverityWarning, CheckLocScope, ln, loc, dir)
+	}
+	return true
+}
+
+// video — проверяет расширение <video:video>
+func (c *validator) video(ln int, loc string, v Video) {
+	required := []struct{ name, value string }{
+		{"thumbnail_loc", v.ThumbnailLoc},
+		{"title", v.Title},
+		{"description", v.Description},
+	}
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			c.v.add(report.SeverityError, CheckVideoRequired, ln, loc, r.name)
+		}
+	}
+	if strings.TrimSpace(v.ContentLoc) == "" && strings.TrimSpace(v.PlayerLoc) == "" {
+		c.v.add(report.SeverityError, CheckVideoContent, ln, loc)
+	}
+	for _, l := range []string{v.ThumbnailLoc, v.ContentLoc, v.PlayerLoc} {
+		if strings.TrimSpace(l) != "" && !absoluteURL(l) {
+			c.v.add(report.SeverityError, CheckVideoLoc, ln, loc, strings.TrimSpace(l))
+		}
+	}
+	if s := strings.TrimSpace(v.Duration); s != "" {
+		if d, err := strconv.Atoi(s); err != nil || d < 1 || d > MaxVideoDuration {
+			c.v.add(report.SeverityError, CheckVideoDuration, ln, loc, s, MaxVideoDuration)
+		}
+	}
+	if s := strings.TrimSpace(v.Rating); s != "" {
+		if r, err := strconv.ParseFloat(s, 64); err != nil || r < 0 || r > 5 {
+			c.v.add(report.SeverityError, CheckVideoRating, ln, loc, s)
+		}
+	}
+	for _, s := range []string{v.PublicationDate, v.ExpirationDate} {
+		if s = strings.TrimSpace(s); s != "" {
+			if _, ok := parseW3CTime(s); !ok {
+				c.v.add(report.SeverityError, CheckVideoDate, ln, loc, s)
+			}
+		}
+	}
+	if s := strings.TrimSpace(v.FamilyFriendly); s != "" && s != "yes" && s != "no" {
+		c.v.add(report.SeverityError, CheckVideoFamily, ln, loc, s)
+	}
+}
+
+// newsEntry — проверяет расширение <news:news>
+func (c *validator) newsEntry(ln int, loc string, n News) {
+	required := []struct{ name, value string }{
+		{"publication/name", n.Publication.Name},
+		{"publication/language", n.Publication.Language},
+		{"publication_date", n.PublicationDate},
+		{"title", n.Title},
+	}
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			c.v.add(report.SeverityError, CheckNewsRequired, ln, loc, r.name)
+		}
+	}
+	if s := strings.TrimSpace(n.Publication.Language); s != "" && !newsLanguage.MatchString(s) {
+		c.v.add(report.SeverityError, CheckNewsLanguage, ln, loc, s)
+	}
+	if s := strings.TrimSpace(n.PublicationDate); s != "" {
+		if _, ok := parseW3CTime(s); !ok {
+			c.v.add(report.SeverityError, CheckNewsDate, ln, loc, s)
+		}
+	}
+}
+
+// nextStart — пропускает пролог и возвращает корневой элемент
+func nextStart(dec *xml.Decoder) (xml.StartElement, error) {
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return xml.StartElement{}, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+		if se, ok := tok.(xml.StartElement); ok {
+			return se, nil
+		}
+	}
+}
+
+func line(dec *xml.Decoder) int {
+	ln, _ := dec.InputPos()
+	return ln
+}
+
+// parseW3CTime — разбирает дату в формате W3C Datetime: от года до времени с долями секунды
+func parseW3CTime(s string) (time.Time, bool) {
+	for _, layout := range lastModLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func absoluteURL(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func scopeRoot(scope *url.URL) string {
+	if scope.Scheme == "" {
+		return scope.Host
+	}
+	return scope.Scheme + "://" + scope.Host
+}
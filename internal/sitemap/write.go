@@ -0,0 +1,46 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// Write — записывает карту сайта urlset с записями urls. Вызывающий отвечает за лимиты
+// протокола: не больше MaxURLs записей в одном файле.
+func Write(w io.Writer, urls []URL) error {
+	doc := struct {
+		XMLName xml.Name `xml:"urlset"`
+		XMLNS   string   `xml:"xmlns,attr"`
+		URLs    []URL    `xml:"url"`
+	}{XMLNS: Namespace, URLs: urls}
+	return encode(w, doc)
+}
+
+// IndexEntry — запись <sitemap> индекса карт сайта
+type IndexEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// WriteIndex — записывает индекс карт сайта sitemapindex
+func WriteIndex(w io.Writer, entries []IndexEntry) error {
+	doc := struct {
+		XMLName  xml.Name     `xml:"sitemapindex"`
+		XMLNS    string       `xml:"xmlns,attr"`
+		Sitemaps []IndexEntry `xml:"sitemap"`
+	}{XMLNS: Namespace, Sitemaps: entries}
+	return encode(w, doc)
+}
+
+func encode(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,287 @@
+package bullwler
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"time"
+
+	"bullwler/internal/analyzer"
+	"bullwler/internal/crawler"
+	"bullwler/internal/events"
+	"bullwler/internal/fetch"
+	"bullwler/internal/rules"
+)
+
+// Option — функциональная опция анализа и сканирования
+type Option func(*options)
+
+type options struct {
+	userAgent    string
+	fetchOpts    []fetch.Option
+	fetcher      *fetch.Fetcher
+	pageTimeout  time.Duration
+	crawlTimeout time.Duration
+	maxDepth     int
+	maxPages     int
+	concurrency  int
+	sitemaps     bool
+	stateDir     string
+	resume       bool
+	include      []*regexp.Regexp
+	exclude      []*regexp.Regexp
+	includeGlob  []string
+	excludeGlob  []string
+	prefixes     []string
+	subdomains   bool
+	hosts        []string
+	sectionPages int
+	onEvent      events.Handler
+
+	registry *rules.Registry
+	// setup — изменения реестра правил, применяемые к копии встроенного реестра
+	setup []func(reg *rules.Registry) error
+}
+
+// newOptions — собирает опции вызова для проверки rawURL: заголовки и cookie
+// отправляются только на хосты этой проверки
+func newOptions(rawURL string, opts []Option) (*options, error) {
+	o := &options{
+		pageTimeout: 15 * time.Second,
+		maxDepth:    3,
+		maxPages:    30,
+		concurrency: 5,
+		sitemaps:    true,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	base := o.registry
+	if base == nil {
+		base = rules.Default()
+	}
+	o.registry = base.Clone()
+	for _, fn := range o.setup {
+		if err := fn(o.registry); err != nil {
+			return nil, err
+		}
+	}
+
+	// Glob-шаблоны дополняют регулярные выражения WithURLFilter, не изменяя переданные срезы
+	include, err := appendGlobs(slices.Clip(o.include), o.includeGlob)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := appendGlobs(slices.Clip(o.exclude), o.excludeGlob)
+	if err != nil {
+		return nil, err
+	}
+	o.include, o.exclude = include, exclude
+
+	fetchOpts := append(slices.Clip(o.fetchOpts),
+		fetch.WithUserAgent(o.userAgent),
+		fetch.WithCredentialHosts(crawler.ScopeHosts(rawURL, o.subdomains, o.hosts)...))
+	fetcher, err := fetch.New(fetchOpts...)
+	if err != nil {
+		return nil, err
+	}
+	o.fetcher = fetcher
+	return o, nil
+}
+
+func appendGlobs(dst []*regexp.Regexp, globs []string) ([]*regexp.Regexp, error) {
+	for _, g := range globs {
+		re, err := crawler.CompileGlob(g)
+		if err != nil {
+			return nil, err
+		}
+		dst = append(dst, re)
+	}
+	return dst, nil
+}
+
+func (o *options) analyzerOptions() []analyzer.Option {
+	opts := []analyzer.Option{
+		analyzer.WithRules(o.registry),
+		analyzer.WithTimeout(o.pageTimeout),
+		analyzer.WithFetcher(o.fetcher),
+	}
+	return opts
+}
+
+func (o *options) crawlerOptions() []crawler.Option {
+	opts := []crawler.Option{
+		crawler.WithMaxDepth(o.maxDepth),
+		crawler.WithMaxPages(o.maxPages),
+		crawler.WithConcurrency(o.concurrency),
+		crawler.WithTimeout(o.crawlTimeout),
+		crawler.WithURLFilter(o.include, o.exclude),
+		crawler.WithAnalyzerOptions(o.analyzerOptions()...),
+		crawler.WithRules(o.registry),
+		crawler.WithFetcher(o.fetcher),
+		crawler.WithSitemaps(o.sitemaps),
+		crawler.WithPathPrefixes(o.prefixes...),
+		crawler.WithSubdomains(o.subdomains),
+		crawler.WithAllowedHosts(o.hosts...),
+		crawler.WithSectionBudget(o.sectionPages),
+	}
+	if o.stateDir != "" {
+		opts = append(opts, crawler.WithState(o.stateDir, o.resume))
+	}
+	if o.onEvent != nil {
+		opts = append(opts, crawler.WithEvents(o.onEvent))
+	}
+	return opts
+}
+
+// WithHTTPClient — задаёт HTTP-клиент для всех запросов: страниц, robots.txt, sitemap.xml
+// и словаря schema.org. Позволяет подключить свой транспорт; опции WithProxy и WithCAFile
+// к такому клиенту не применяются.
+func WithHTTPClient(c *http.Client) Option {
+	return func(o *options) { o.fetchOpts = append(o.fetchOpts, fetch.WithHTTPClient(c)) }
+}
+
+// WithHeader — добавляет заголовок к запросам на проверяемый сайт, например Authorization
+// для закрытого стенда; на хосты вне сканирования (schema.org, внешние ресурсы) он не отправляется
+func WithHeader(name, value string) Option {
+	return func(o *options) { o.fetchOpts = append(o.fetchOpts, fetch.WithHeader(name, value)) }
+}
+
+// WithCookie — добавляет cookie вида "name=value" к запросам на проверяемый сайт
+func WithCookie(raw string) Option {
+	return func(o *options) { o.fetchOpts = append(o.fetchOpts, fetch.WithCookie(raw)) }
+}
+
+// WithProxy — задаёт прокси-сервер; по умолчанию используются переменные окружения HTTP_PROXY и HTTPS_PROXY
+func WithProxy(rawURL string) Option {
+	return func(o *options) { o.fetchOpts = append(o.fetchOpts, fetch.WithProxy(rawURL)) }
+}
+
+// WithCAFile — добавляет корневые сертификаты из PEM-файла к системным
+func WithCAFile(path string) Option {
+	return func(o *options) { o.fetchOpts = append(o.fetchOpts, fetch.WithCAFile(path)) }
+}
+
+// WithMaxBodySize — ограничивает размер читаемого тела ответа в байтах (по умолчанию 10 МиБ, 0 — без ограничения)
+func WithMaxBodySize(n int64) Option {
+	return func(o *options) { o.fetchOpts = append(o.fetchOpts, fetch.WithMaxBodySize(n)) }
+}
+
+// WithUserAgent — задаёт, от имени какого краулера идут запросы и проверяется robots.txt:
+// имя пресета из Agents (googlebot-smartphone, bingbot, gptbot, claudebot и т. д.) или строка User-Agent
+func WithUserAgent(ua string) Option { return func(o *options) { o.userAgent = ua } }
+
+// WithPageTimeout — задаёт таймаут загрузки одной страницы (по умолчанию 15s)
+func WithPageTimeout(d time.Duration) Option { return func(o *options) { o.pageTimeout = d } }
+
+// WithCrawlTimeout — задаёт общий лимит времени на сканирование сайта (по умолчанию без ограничения)
+func WithCrawlTimeout(d time.Duration) Option { return func(o *options) { o.crawlTimeout = d } }
+
+// WithMaxDepth — задаёт максимальную глубину сканирования (по умолчанию 3)
+func WithMaxDepth(d int) Option { return func(o *options) { o.maxDepth = d } }
+
+// WithMaxPages — задаёт максимальное количество страниц (по умолчанию 30)
+func WithMaxPages(n int) Option { return func(o *options) { o.maxPages = n } }
+
+// WithConcurrency — задаёт количество параллельных загрузок (по умолчанию 5)
+func WithConcurrency(n int) Option { return func(o *options) { o.concurrency = n } }
+
+// WithSitemaps — включает или выключает использование карт сайта при сканировании:
+// их записи становятся точками входа, а в SiteReport.Sitemap попадает покрытие сайта картами
+// (по умолчанию включено)
+func WithSitemaps(enabled bool) Option { return func(o *options) { o.sitemaps = enabled } }
+
+// WithState — сохраняет очередь и результаты сканирования в директорию dir, чтобы прерванное
+// сканирование можно было продолжить; с resume Crawl продолжает с сохранённого места
+// и не загружает повторно уже просканированные страницы
+func WithState(dir string, resume bool) Option {
+	return func(o *options) {
+		o.stateDir = dir
+		o.resume = resume
+	}
+}
+
+// WithURLFilter — задаёт шаблоны URL сканирования: при непустом include ссылка должна
+// совпасть хотя бы с одним из них, совпадение с exclude исключает ссылку
+func WithURLFilter(include, exclude []*regexp.Regexp) Option {
+	return func(o *options) {
+		o.include = include
+		o.exclude = exclude
+	}
+}
+
+// WithURLGlobs — задаёт шаблоны URL сканирования в виде glob: "/blog/**" — раздел на любом хосте,
+// "https://*.example.com/**" — URL целиком; "*" не пересекает "/", "**" — пересекает.
+// Шаблоны добавляются к регулярным выражениям WithURLFilter и действуют так же.
+func WithURLGlobs(include, exclude []string) Option {
+	return func(o *options) {
+		o.includeGlob = append(o.includeGlob, include...)
+		o.excludeGlob = append(o.excludeGlob, exclude...)
+	}
+}
+
+// WithPathPrefixes — ограничивает сканирование разделами сайта ("/blog/", "/docs"):
+// ссылки вне этих путей не сканируются; стартовая страница сканируется всегда
+func WithPathPrefixes(prefixes ...string) Option {
+	return func(o *options) { o.prefixes = append(o.prefixes, prefixes...) }
+}
+
+// WithSubdomains — переходит по ссылкам на поддомены стартового хоста (по умолчанию выключено)
+func WithSubdomains(enabled bool) Option { return func(o *options) { o.subdomains = enabled } }
+
+// WithAllowedHosts — добавляет хосты, ссылки на которые сканируются наравне со стартовым;
+// "*.example.com" разрешает домен и все его поддомены
+func WithAllowedHosts(hosts ...string) Option {
+	return func(o *options) { o.hosts = append(o.hosts, hosts...) }
+}
+
+// WithSectionBudget — ограничивает число страниц одного раздела сайта — первого сегмента пути
+// после префикса WithPathPrefixes (по умолчанию без ограничения)
+func WithSectionBudget(n int) Option { return func(o *options) { o.sectionPages = n } }
+
+// WithEvents — задаёт получателя событий сканирования. Обработчик вызывается
+// из рабочих горутин и должен быть потокобезопасным.
+func WithEvents(h func(Event)) Option { return func(o *options) { o.onEvent = h } }
+
+// WithRegistry — задаёт реестр правил вместо встроенного; опции WithRule,
+// DisableRules и WithThreshold применяются к его копии
+func WithRegistry(reg *Registry) Option { return func(o *options) { o.registry = reg } }
+
+// WithRule — добавляет пользовательские правила к встроенным
+func WithRule(rs ...Rule) Option {
+	return func(o *options) {
+		o.setup = append(o.setup, func(reg *rules.Registry) error {
+			for _, r := range rs {
+				if err := reg.Register(r); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+}
+
+// DisableRules — выключает правила по идентификаторам
+func DisableRules(ids ...string) Option {
+	return func(o *options) {
+		o.setup = append(o.setup, func(reg *rules.Registry) error {
+			for _, id := range ids {
+				if err := reg.SetEnabled(id, false); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+}
+
+// WithThreshold — переопределяет порог правила, например
+// WithThreshold("performance.response.slow", "max_ms", 1500)
+func WithThreshold(ruleID, param string, value float64) Option {
+	return func(o *options) {
+		o.setup = append(o.setup, func(reg *rules.Registry) error {
+			return reg.SetParam(ruleID, param, value)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package bullwler
+
+import (
+	"bullwler/internal/events"
+	"bullwler/internal/fetch"
+	"bullwler/internal/report"
+	"bullwler/internal/rules"
+)
+
+// Rule — проверка страницы; реализуется пользовательскими правилами
+type Rule = rules.Rule
+
+// RuleMeta — метаданные правила: описание, пороги по умолчанию и вклад в AI Readiness Score
+type RuleMeta = rules.Meta
+
+// RuleInfo — описание правила: идентификатор, категория, серьёзность, название и рекомендация
+type RuleInfo = report.RuleInfo
+
+// RuleContext — данные, доступные правилу во время проверки: DOM, HTTP-ответ и отчёт
+type RuleContext = rules.Context
+
+// Registry — набор правил с переключателями и порогами
+type Registry = rules.Registry
+
+// Event — событие сканирования
+type Event = events.Event
+
+// Agent — идентичность краулера: User-Agent запросов и токен robots.txt
+type Agent = fetch.Agent
+
+// NewRule — создаёт правило из метаданных и функции проверки:
+//
+//	rule := bullwler.NewRule(bullwler.RuleMeta{RuleInfo: bullwler.RuleInfo{
+//		ID: "cms.author.missing", Category: bullwler.CategorySEO, Severity: bullwler.SeverityWarning,
+//		Title: "Не указан автор", Remediation: "Заполните поле «Автор» в CMS",
+//	}}, func(c *bullwler.RuleContext) {
+//		if !c.Report.HasAuthor {
+//			c.Add("Не указан автор материала", nil)
+//		}
+//	})
+func NewRule(meta RuleMeta, check func(c *RuleContext)) Rule {
+	return rules.New(meta, check)
+}
+
+// DefaultRules — копия реестра встроенных правил
+func DefaultRules() *Registry {
+	return rules.Default().Clone()
+}
+
+// Rules — каталог встроенных правил. Пользовательские правила в него не попадают:
+// они видны только вызовам, в опциях которых подключены (WithRule, WithRegistry),
+// а их описания возвращает Registry.Catalog.
+func Rules() []RuleInfo {
+	return report.Rules()
+}
+
+// Agents — встроенные пресеты поисковых и AI-краулеров для WithUserAgent
+func Agents() []Agent {
+	return fetch.Presets()
+}